@@ -25,12 +25,23 @@ const (
 
 	formMediaType = "application/x-www-form-urlencoded"
 	jsonMediaType = "application/json"
+
+	// slowDownInterval is how much the polling interval grows every time the
+	// server responds with slow_down, as required by RFC 8628 section 3.5.
+	slowDownInterval = 5 * time.Second
 )
 
+// ErrSlowDown is returned when the authorization server asks the client to
+// poll less frequently for the access token. GetTokensForDevice handles it by
+// raising the polling interval, and passes it on to the function set with
+// WithSlowDownHandler.
+var ErrSlowDown = errors.New("authorization server requested a slower polling interval")
+
 // Authenticator is the interface for authentication via device oauth
 type Authenticator interface {
 	VerifyDevice(ctx context.Context) (*DeviceVerification, error)
 	GetAccessTokenForDevice(ctx context.Context, v *DeviceVerification) (string, error)
+	GetTokensForDevice(ctx context.Context, v *DeviceVerification) (*OAuthTokenResponse, error)
 	RevokeToken(ctx context.Context, token string) error
 }
 
@@ -59,6 +70,16 @@ func WithMockClock(mock *clock.Mock) AuthenticatorOption {
 	}
 }
 
+// WithSlowDownHandler sets a function that is called every time the
+// authorization server asks to poll less frequently while waiting for the
+// device to be authorized. err wraps ErrSlowDown and has the new interval.
+func WithSlowDownHandler(fn func(err error)) AuthenticatorOption {
+	return func(d *DeviceAuthenticator) error {
+		d.slowDownHandler = fn
+		return nil
+	}
+}
+
 // DeviceCodeResponse encapsulates the response for obtaining a device code.
 type DeviceCodeResponse struct {
 	DeviceCode              string `json:"device_code"`
@@ -96,6 +117,8 @@ type DeviceAuthenticator struct {
 	Clock        clock.Clock
 	ClientID     string
 	ClientSecret string
+
+	slowDownHandler func(err error)
 }
 
 // New returns an instance of the DeviceAuthenticator
@@ -173,23 +196,44 @@ func (d *DeviceAuthenticator) VerifyDevice(ctx context.Context) (*DeviceVerifica
 // GetAccessTokenForDevice uses the device verification response to fetch an
 // access token.
 func (d *DeviceAuthenticator) GetAccessTokenForDevice(ctx context.Context, v *DeviceVerification) (string, error) {
-	var accessToken string
+	tokens, err := d.GetTokensForDevice(ctx, v)
+	if err != nil {
+		return "", err
+	}
+
+	return tokens.AccessToken, nil
+}
+
+// GetTokensForDevice uses the device verification response to fetch an access
+// token along with the refresh token that can be used to renew it.
+func (d *DeviceAuthenticator) GetTokensForDevice(ctx context.Context, v *DeviceVerification) (*OAuthTokenResponse, error) {
+	var tokens *OAuthTokenResponse
 	var err error
 
+	// The interval is kept here rather than on v, as v is owned by the caller.
+	interval := v.CheckInterval
+	// The first request is made right away, as the user may have already
+	// authorized the device. The interval is only waited between retries.
 	for {
-		time.Sleep(v.CheckInterval)
-		accessToken, err = d.requestToken(ctx, v.DeviceCode, d.ClientID)
-		if accessToken == "" && err == nil {
-			if d.Clock.Now().After(v.ExpiresAt) {
-				err = errors.New("authentication timed out")
-			} else {
-				continue
+		tokens, err = d.requestToken(ctx, v.DeviceCode, d.ClientID)
+		if err == ErrSlowDown {
+			interval += slowDownInterval
+			if d.slowDownHandler != nil {
+				d.slowDownHandler(errors.Wrapf(err, "polling every %s", interval))
 			}
+			err = nil
 		}
 
-		break
+		if tokens != nil || err != nil {
+			return tokens, err
+		}
+
+		if d.Clock.Now().After(v.ExpiresAt) {
+			return nil, errors.New("authentication timed out")
+		}
+
+		d.Clock.Sleep(interval)
 	}
-	return accessToken, err
 }
 
 // OAuthTokenResponse contains the information returned after fetching an access
@@ -201,38 +245,74 @@ type OAuthTokenResponse struct {
 	ExpiresIn    int    `json:"expires_in"`
 }
 
-func (d *DeviceAuthenticator) requestToken(ctx context.Context, deviceCode string, clientID string) (string, error) {
+func (d *DeviceAuthenticator) requestToken(ctx context.Context, deviceCode string, clientID string) (*OAuthTokenResponse, error) {
 	payload := strings.NewReader(fmt.Sprintf("grant_type=urn:ietf:params:oauth:grant-type:device_code&device_code=%s&client_id=%s", deviceCode, clientID))
 	req, err := d.NewFormRequest(ctx, http.MethodPost, "oauth/token", payload)
 	if err != nil {
-		return "", errors.Wrap(err, "error creating request")
+		return nil, errors.Wrap(err, "error creating request")
 	}
 
 	res, err := d.client.Do(req)
 	if err != nil {
-		return "", errors.Wrap(err, "error performing http request")
+		return nil, errors.Wrap(err, "error performing http request")
 	}
 
 	defer res.Body.Close()
 
+	// A slow_down response is returned as ErrSlowDown, so the caller can back
+	// off before retrying.
 	isRetryable, err := checkErrorResponse(res)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Bail early so the token fetching is retried.
 	if isRetryable {
-		return "", nil
+		return nil, nil
 	}
 
 	tokenRes := &OAuthTokenResponse{}
 
 	err = json.NewDecoder(res.Body).Decode(tokenRes)
 	if err != nil {
-		return "", errors.Wrap(err, "error decoding token response")
+		return nil, errors.Wrap(err, "error decoding token response")
+	}
+
+	return tokenRes, nil
+}
+
+// RefreshAccessToken exchanges a refresh token for a new access token. The
+// response's refresh token is set if the server rotated the refresh token,
+// in which case the old one can't be used again.
+func (d *DeviceAuthenticator) RefreshAccessToken(ctx context.Context, refreshToken, clientID string) (*OAuthTokenResponse, error) {
+	payload := strings.NewReader(fmt.Sprintf("grant_type=refresh_token&refresh_token=%s&client_id=%s", url.QueryEscape(refreshToken), clientID))
+	req, err := d.NewFormRequest(ctx, http.MethodPost, "oauth/token", payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request")
+	}
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "error performing http request")
+	}
+
+	defer res.Body.Close()
+
+	if _, err = checkErrorResponse(res); err != nil {
+		return nil, err
+	}
+
+	tokenRes := &OAuthTokenResponse{}
+	err = json.NewDecoder(res.Body).Decode(tokenRes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding token response")
+	}
+
+	if tokenRes.AccessToken == "" {
+		return nil, errors.New("token response did not contain an access token")
 	}
 
-	return tokenRes.AccessToken, nil
+	return tokenRes, nil
 }
 
 // RevokeToken revokes an access token.
@@ -285,7 +365,7 @@ func (d *DeviceAuthenticator) NewFormRequest(ctx context.Context, method string,
 }
 
 // checkErrorResponse returns whether the error is retryable or not and the
-// error itself.
+// error itself. A slow_down response is retryable and returns ErrSlowDown.
 func checkErrorResponse(res *http.Response) (bool, error) {
 	if res.StatusCode >= 400 {
 		errorRes := &ErrorResponse{}
@@ -296,8 +376,11 @@ func checkErrorResponse(res *http.Response) (bool, error) {
 
 		// If we're polling and haven't authorized yet or we need to slow down, we
 		// don't wanna terminate the polling
-		if errorRes.ErrorCode == "authorization_pending" || errorRes.ErrorCode == "slow_down" {
+		switch errorRes.ErrorCode {
+		case "authorization_pending":
 			return true, nil
+		case "slow_down":
+			return true, ErrSlowDown
 		}
 
 		return false, errorRes
@@ -125,3 +125,130 @@ func setupServer(fn func(mux *http.ServeMux)) (*httptest.Server, func()) {
 		server.Close()
 	}
 }
+
+func TestGetAccessTokenForDevice_SlowDown(t *testing.T) {
+	mockClock := clock.NewMock()
+
+	var requestedAt []time.Time
+	srv, cleanup := setupServer(func(mux *http.ServeMux) {
+		mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+			requestedAt = append(requestedAt, mockClock.Now())
+
+			if len(requestedAt) == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, err := w.Write([]byte(`{"error": "slow_down", "error_description": "slow down"}`))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			_, err := w.Write([]byte(`{"access_token": "some-access-token"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	})
+	t.Cleanup(cleanup)
+
+	var slowDowns []error
+	authenticator, err := New(cleanhttp.DefaultClient(), testClientID, testClientSecret, SetBaseURL(srv.URL), WithMockClock(mockClock),
+		WithSlowDownHandler(func(err error) { slowDowns = append(slowDowns, err) }))
+	if err != nil {
+		t.Fatalf("error creating client: %s", err.Error())
+	}
+
+	v := &DeviceVerification{
+		DeviceCode:    "some_device_code",
+		CheckInterval: time.Second,
+		ExpiresAt:     mockClock.Now().Add(time.Hour),
+	}
+
+	type result struct {
+		token string
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		token, err := authenticator.GetAccessTokenForDevice(context.TODO(), v)
+		done <- result{token: token, err: err}
+	}()
+
+	var res result
+loop:
+	for {
+		select {
+		case res = <-done:
+			break loop
+		default:
+			mockClock.Add(500 * time.Millisecond)
+		}
+	}
+
+	assert.NoError(t, res.err)
+	assert.Equal(t, "some-access-token", res.token)
+	assert.Len(t, requestedAt, 2)
+	assert.GreaterOrEqual(t, int64(requestedAt[1].Sub(requestedAt[0])), int64(time.Second+slowDownInterval))
+	assert.Equal(t, time.Second, v.CheckInterval, "caller-owned verification must not be modified")
+	if assert.Len(t, slowDowns, 1) {
+		assert.ErrorIs(t, slowDowns[0], ErrSlowDown)
+		assert.Contains(t, slowDowns[0].Error(), "polling every 6s")
+	}
+}
+
+func TestGetAccessTokenForDevice_Immediate(t *testing.T) {
+	srv, cleanup := setupServer(func(mux *http.ServeMux) {
+		mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{"access_token": "some-access-token"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	})
+	t.Cleanup(cleanup)
+
+	// the mock clock is never advanced, so this only returns if the first
+	// request is made without sleeping.
+	mockClock := clock.NewMock()
+	authenticator, err := New(cleanhttp.DefaultClient(), testClientID, testClientSecret, SetBaseURL(srv.URL), WithMockClock(mockClock))
+	if err != nil {
+		t.Fatalf("error creating client: %s", err.Error())
+	}
+
+	token, err := authenticator.GetAccessTokenForDevice(context.TODO(), &DeviceVerification{
+		DeviceCode:    "some_device_code",
+		CheckInterval: 5 * time.Second,
+		ExpiresAt:     mockClock.Now().Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "some-access-token", token)
+}
+
+func TestRefreshAccessToken(t *testing.T) {
+	srv, cleanup := setupServer(func(mux *http.ServeMux) {
+		mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+			payload, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, "grant_type=refresh_token&refresh_token=some-refresh-token&client_id=some-client-id", string(payload))
+
+			_, err = w.Write([]byte(`{"access_token": "new-access-token", "refresh_token": "some-refresh-token"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	})
+	t.Cleanup(cleanup)
+
+	authenticator, err := New(cleanhttp.DefaultClient(), testClientID, testClientSecret, SetBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("error creating client: %s", err.Error())
+	}
+
+	tokens, err := authenticator.RefreshAccessToken(context.TODO(), "some-refresh-token", testClientID)
+	assert.NoError(t, err)
+	assert.Equal(t, "new-access-token", tokens.AccessToken)
+	assert.Equal(t, "some-refresh-token", tokens.RefreshToken)
+}
@@ -101,7 +101,7 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 			}
 			end()
 
-			if len(auditLogs) == 0 && ch.Printer.Format() == printer.Human {
+			if len(auditLogs) == 0 && ch.Printer.IsHuman() {
 				ch.Printer.Printf("No audit logs exist for organization %s.\n",
 					printer.BoldBlue(ch.Config.Organization))
 				return nil
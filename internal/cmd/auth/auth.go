@@ -15,5 +15,7 @@ func AuthCmd(ch *cmdutil.Helper) *cobra.Command {
 
 	cmd.AddCommand(LoginCmd(ch))
 	cmd.AddCommand(LogoutCmd(ch))
+	cmd.AddCommand(RefreshCmd(ch))
+	cmd.AddCommand(StatusCmd(ch))
 	return cmd
 }
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"runtime"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/hashicorp/go-cleanhttp"
+	"github.com/mattn/go-isatty"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -23,72 +25,230 @@ func LoginCmd(ch *cmdutil.Helper) *cobra.Command {
 	var clientID string
 	var clientSecret string
 	var authURL string
+	var check bool
+	var outputToken bool
+	var refreshTokenFile string
+	var once bool
 
 	cmd := &cobra.Command{
 		Use:   "login",
 		Args:  cobra.ExactArgs(0),
 		Short: "Authenticate with the PlanetScale API",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !printer.IsTTY {
-				return errors.New("The 'login' command requires an interactive shell")
+			if once {
+				if check || outputToken || refreshTokenFile != "" {
+					return errors.New("--once can't be used with --check, --output-token or --output-refresh-token-file")
+				}
+				return loginOnce(cmd, ch, clientID, clientSecret, authURL)
 			}
 
-			authenticator, err := auth.New(cleanhttp.DefaultClient(), clientID, clientSecret, auth.SetBaseURL(authURL))
-			if err != nil {
-				return err
+			if check {
+				return checkLogin(cmd.Context(), ch)
 			}
 
-			ctx := cmd.Context()
-
-			deviceVerification, err := authenticator.VerifyDevice(ctx)
-			if err != nil {
-				return err
+			if outputToken {
+				return loginWithTokenOutput(cmd, ch, clientID, clientSecret, authURL, refreshTokenFile)
 			}
 
-			openCmd := cmdutil.OpenBrowser(runtime.GOOS, deviceVerification.VerificationCompleteURL)
-			err = openCmd.Run()
-			if err != nil {
-				ch.Printer.Printf("Failed to open a browser: %s\n", printer.BoldRed(err.Error()))
+			if refreshTokenFile != "" {
+				return errors.New("--output-refresh-token-file can only be used with --output-token")
 			}
 
-			bold := color.New(color.Bold)
-			bold.Printf("\nConfirmation Code: ")
-			boldGreen := bold.Add(color.FgGreen)
-			boldGreen.Fprintln(color.Output, deviceVerification.UserCode)
+			return login(cmd.Context(), ch, clientID, clientSecret, authURL)
+		},
+	}
 
-			ch.Printer.Printf("\nIf something goes wrong, copy and paste this URL into your browser: %s\n\n", printer.Bold(deviceVerification.VerificationCompleteURL))
+	cmd.Flags().StringVar(&clientID, "client-id", auth.OAuthClientID, "The client ID for the PlanetScale CLI application.")
+	cmd.Flags().StringVar(&clientSecret, "client-secret", auth.OAuthClientSecret, "The client ID for the PlanetScale CLI application")
+	cmd.Flags().StringVar(&authURL, "api-url", auth.DefaultBaseURL, "The PlanetScale Auth API base URL.")
+	cmd.Flags().BoolVar(&check, "check", false, "Check whether the current credentials are valid without logging in again.")
+	cmd.Flags().BoolVar(&outputToken, "output-token", false,
+		"Print the access token to stdout after logging in. All other messages are written to stderr.")
+	cmd.Flags().StringVar(&refreshTokenFile, "output-refresh-token-file", "",
+		"Write the refresh token to this file after logging in. Requires --output-token.")
+	cmd.Flags().BoolVar(&once, "once", false,
+		"Print the access token to stdout without saving it or changing the config file, for use with --api-token or PLANETSCALE_API_TOKEN. All other messages are written to stderr.")
 
-			end := ch.Printer.PrintProgress("Waiting for confirmation...")
-			defer end()
-			accessToken, err := authenticator.GetAccessTokenForDevice(ctx, deviceVerification)
-			if err != nil {
-				return err
-			}
+	return cmd
+}
 
-			err = writeAccessToken(ctx, accessToken)
-			if err != nil {
-				return errors.Wrap(err, "error logging in")
-			}
+// login authenticates with the device flow and stores the resulting tokens.
+func login(ctx context.Context, ch *cmdutil.Helper, clientID, clientSecret, authURL string) error {
+	if !printer.IsTTY {
+		return errors.New("The 'login' command requires an interactive shell")
+	}
 
-			// We explicitly stop here so we can replace the spinner with our success
-			// message.
-			end()
-			ch.Printer.Println("Successfully logged in.")
+	_, err := deviceLogin(ctx, ch, clientID, clientSecret, authURL)
+	return err
+}
+
+// loginWithTokenOutput logs in like login, but prints the access token to
+// stdout so it can be captured by scripts. As stdout isn't a terminal then,
+// the messages for the device flow are written to stderr instead.
+func loginWithTokenOutput(cmd *cobra.Command, ch *cmdutil.Helper, clientID, clientSecret, authURL, refreshTokenFile string) error {
+	if !isatty.IsTerminal(os.Stderr.Fd()) && !isatty.IsCygwinTerminal(os.Stderr.Fd()) {
+		return errors.New("The 'login' command requires an interactive shell")
+	}
 
-			err = writeDefaultOrganization(ctx, accessToken, authURL)
-			if err != nil {
-				return err
+	ch.Printer.SetHumanOutput(os.Stderr)
+
+	tokens, err := deviceLogin(cmd.Context(), ch, clientID, clientSecret, authURL)
+	if err != nil {
+		return err
+	}
+
+	if refreshTokenFile != "" {
+		if tokens.RefreshToken == "" {
+			return errors.New("no refresh token was issued for this login")
+		}
+
+		err := ioutil.WriteFile(refreshTokenFile, []byte(tokens.RefreshToken), config.TokenFileMode)
+		if err != nil {
+			return errors.Wrap(err, "error writing refresh token")
+		}
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), tokens.AccessToken)
+	return nil
+}
+
+// loginOnce authenticates with the device flow and prints the access token
+// to stdout, without storing the tokens or writing the config file.
+func loginOnce(cmd *cobra.Command, ch *cmdutil.Helper, clientID, clientSecret, authURL string) error {
+	ch.Printer.SetHumanOutput(os.Stderr)
+
+	tokens, err := deviceAuthenticate(cmd.Context(), ch, clientID, clientSecret, authURL)
+	if err != nil {
+		return err
+	}
+
+	if !ch.Printer.IsHuman() {
+		return ch.Printer.PrintResource(&accessToken{
+			AccessToken: tokens.AccessToken,
+			ExpiresIn:   tokens.ExpiresIn,
+		})
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), tokens.AccessToken)
+	return nil
+}
+
+// accessToken is the printable access token of login --once.
+type accessToken struct {
+	AccessToken string `header:"access token" json:"access_token" csv:"access_token"`
+	ExpiresIn   int    `header:"expires in" json:"expires_in" csv:"expires_in"`
+}
+
+func (a *accessToken) MarshalCSVValue() interface{} {
+	return []*accessToken{a}
+}
+
+// newAuthenticator returns the authenticator for the device flow.
+var newAuthenticator = func(clientID, clientSecret, authURL string, opts ...auth.AuthenticatorOption) (auth.Authenticator, error) {
+	opts = append([]auth.AuthenticatorOption{auth.SetBaseURL(authURL)}, opts...)
+	return auth.New(cleanhttp.DefaultClient(), clientID, clientSecret, opts...)
+}
+
+// deviceAuthenticate authenticates with the device flow and returns the
+// issued tokens.
+func deviceAuthenticate(ctx context.Context, ch *cmdutil.Helper, clientID, clientSecret, authURL string) (*auth.OAuthTokenResponse, error) {
+	// being throttled only makes the login slower, so it's only reported
+	// for debugging
+	authenticator, err := newAuthenticator(clientID, clientSecret, authURL,
+		auth.WithSlowDownHandler(func(err error) {
+			if ch.Debug() {
+				ch.Printer.Printf("\n%s\n", err)
 			}
+		}))
+	if err != nil {
+		return nil, err
+	}
 
-			return nil
-		},
+	deviceVerification, err := authenticator.VerifyDevice(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	cmd.Flags().StringVar(&clientID, "client-id", auth.OAuthClientID, "The client ID for the PlanetScale CLI application.")
-	cmd.Flags().StringVar(&clientSecret, "client-secret", auth.OAuthClientSecret, "The client ID for the PlanetScale CLI application")
-	cmd.Flags().StringVar(&authURL, "api-url", auth.DefaultBaseURL, "The PlanetScale Auth API base URL.")
+	// the browser can only be opened from a terminal
+	if printer.IsTTY {
+		openCmd := cmdutil.OpenBrowser(runtime.GOOS, deviceVerification.VerificationCompleteURL)
+		err = openCmd.Run()
+		if err != nil {
+			ch.Printer.Printf("Failed to open a browser: %s\n", printer.BoldRed(err.Error()))
+		}
+	}
 
-	return cmd
+	bold := color.New(color.Bold)
+	boldGreen := color.New(color.Bold, color.FgGreen)
+	ch.Printer.Printf("\n%s%s\n", bold.Sprint("Confirmation Code: "), boldGreen.Sprint(deviceVerification.UserCode))
+
+	ch.Printer.Printf("\nIf something goes wrong, copy and paste this URL into your browser: %s\n\n", printer.Bold(deviceVerification.VerificationCompleteURL))
+
+	end := ch.Printer.PrintProgress("Waiting for confirmation...")
+	defer end()
+	return authenticator.GetTokensForDevice(ctx, deviceVerification)
+}
+
+// deviceLogin authenticates with the device flow, stores the resulting tokens
+// and returns them.
+func deviceLogin(ctx context.Context, ch *cmdutil.Helper, clientID, clientSecret, authURL string) (*auth.OAuthTokenResponse, error) {
+	tokens, err := deviceAuthenticate(ctx, ch, clientID, clientSecret, authURL)
+	if err != nil {
+		return nil, err
+	}
+	accessToken := tokens.AccessToken
+
+	err = writeAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "error logging in")
+	}
+
+	if tokens.RefreshToken != "" {
+		err = writeRefreshToken(ctx, tokens.RefreshToken)
+		if err != nil {
+			return nil, errors.Wrap(err, "error logging in")
+		}
+	}
+
+	ch.Printer.Println("Successfully logged in.")
+
+	err = writeDefaultOrganization(ctx, accessToken, authURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// checkLogin verifies the current credentials with an API call. It returns an
+// error with a non-zero exit code if they're missing or rejected.
+func checkLogin(ctx context.Context, ch *cmdutil.Helper) error {
+	if !ch.Config.IsAuthenticated() {
+		return &cmdutil.Error{
+			Msg:      "not logged in, run 'pscale auth login' to authenticate",
+			ExitCode: 1,
+		}
+	}
+
+	client, err := ch.Client()
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Organizations.List(ctx); err != nil {
+		switch cmdutil.ErrCode(err) {
+		case planetscale.ErrPermission:
+			return &cmdutil.Error{
+				Msg:      "the current credentials are invalid, run 'pscale auth login' to authenticate again",
+				ExitCode: 1,
+			}
+		default:
+			return cmdutil.HandleError(err)
+		}
+	}
+
+	ch.Printer.Println("The current credentials are valid.")
+	return nil
 }
 
 func writeDefaultOrganization(ctx context.Context, accessToken, authURL string) error {
@@ -122,6 +282,26 @@ func writeDefaultOrganization(ctx context.Context, accessToken, authURL string)
 }
 
 func writeAccessToken(ctx context.Context, accessToken string) error {
+	tokenPath, err := config.AccessTokenPath()
+	if err != nil {
+		return err
+	}
+
+	return writeToken(tokenPath, accessToken)
+}
+
+func writeRefreshToken(ctx context.Context, refreshToken string) error {
+	tokenPath, err := config.RefreshTokenPath()
+	if err != nil {
+		return err
+	}
+
+	return writeToken(tokenPath, refreshToken)
+}
+
+// writeToken writes the token to the given path in the config directory,
+// creating the directory if needed.
+func writeToken(tokenPath, token string) error {
 	configDir, err := config.ConfigDir()
 	if err != nil {
 		return err
@@ -137,12 +317,7 @@ func writeAccessToken(ctx context.Context, accessToken string) error {
 		return err
 	}
 
-	tokenPath, err := config.AccessTokenPath()
-	if err != nil {
-		return err
-	}
-
-	tokenBytes := []byte(accessToken)
+	tokenBytes := []byte(token)
 	err = ioutil.WriteFile(tokenPath, tokenBytes, config.TokenFileMode)
 	if err != nil {
 		return errors.Wrap(err, "error writing token")
@@ -0,0 +1,242 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/planetscale/cli/internal/auth"
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/config"
+	"github.com/planetscale/cli/internal/mock"
+	"github.com/planetscale/cli/internal/printer"
+	ps "github.com/planetscale/planetscale-go/planetscale"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/mitchellh/go-homedir"
+)
+
+func TestLogin_Check(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(ioutil.Discard)
+
+	svc := &mock.OrganizationsService{
+		ListFn: func(ctx context.Context) ([]*ps.Organization, error) {
+			return []*ps.Organization{{Name: "planetscale"}}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			AccessToken: "token",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Organizations: svc,
+			}, nil
+		},
+	}
+
+	cmd := LoginCmd(ch)
+	cmd.SetArgs([]string{"--check"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListFnInvoked, qt.IsTrue)
+}
+
+func TestLogin_CheckInvalid(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(ioutil.Discard)
+
+	svc := &mock.OrganizationsService{
+		ListFn: func(ctx context.Context) ([]*ps.Organization, error) {
+			return nil, &ps.Error{Code: ps.ErrPermission}
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			AccessToken: "token",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Organizations: svc,
+			}, nil
+		},
+	}
+
+	cmd := LoginCmd(ch)
+	cmd.SetArgs([]string{"--check"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "the current credentials are invalid.*")
+	c.Assert(svc.ListFnInvoked, qt.IsTrue)
+}
+
+func TestLogin_RefreshTokenFileRequiresOutputToken(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(ioutil.Discard)
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config:  &config.Config{},
+	}
+
+	cmd := LoginCmd(ch)
+	cmd.SetArgs([]string{"--output-refresh-token-file", "token"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "--output-refresh-token-file can only be used with --output-token")
+}
+
+type fakeAuthenticator struct {
+	tokens *auth.OAuthTokenResponse
+}
+
+func (f *fakeAuthenticator) VerifyDevice(ctx context.Context) (*auth.DeviceVerification, error) {
+	return &auth.DeviceVerification{
+		UserCode:                "ABCD-EFGH",
+		VerificationCompleteURL: "https://auth.planetscale.com/activate?code=ABCD-EFGH",
+	}, nil
+}
+
+func (f *fakeAuthenticator) GetAccessTokenForDevice(ctx context.Context, v *auth.DeviceVerification) (string, error) {
+	return f.tokens.AccessToken, nil
+}
+
+func (f *fakeAuthenticator) GetTokensForDevice(ctx context.Context, v *auth.DeviceVerification) (*auth.OAuthTokenResponse, error) {
+	return f.tokens, nil
+}
+
+func (f *fakeAuthenticator) RevokeToken(ctx context.Context, token string) error {
+	return nil
+}
+
+// setupLoginOnce replaces the authenticator with a fake one and the home
+// directory with an empty one. It returns the home directory.
+func setupLoginOnce(c *qt.C) string {
+	oldAuthenticator := newAuthenticator
+	newAuthenticator = func(clientID, clientSecret, authURL string, opts ...auth.AuthenticatorOption) (auth.Authenticator, error) {
+		return &fakeAuthenticator{tokens: &auth.OAuthTokenResponse{
+			AccessToken:  "access-token",
+			RefreshToken: "refresh-token",
+			ExpiresIn:    3600,
+		}}, nil
+	}
+
+	home := c.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", home)
+	homedir.DisableCache = true
+
+	c.Cleanup(func() {
+		newAuthenticator = oldAuthenticator
+		os.Setenv("HOME", oldHome)
+		homedir.DisableCache = false
+	})
+
+	return home
+}
+
+// assertEmptyDir checks that nothing was written to the directory.
+func assertEmptyDir(c *qt.C, dir string) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir {
+			files = append(files, path)
+		}
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(files, qt.HasLen, 0)
+}
+
+func TestLogin_Once(t *testing.T) {
+	c := qt.New(t)
+	home := setupLoginOnce(c)
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config:  &config.Config{},
+	}
+
+	var buf bytes.Buffer
+	cmd := LoginCmd(ch)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--once"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.Equals, "access-token\n")
+	assertEmptyDir(c, home)
+}
+
+func TestLogin_OnceJSON(t *testing.T) {
+	c := qt.New(t)
+	home := setupLoginOnce(c)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config:  &config.Config{},
+	}
+
+	cmd := LoginCmd(ch)
+	cmd.SetArgs([]string{"--once"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.JSONEquals, map[string]interface{}{
+		"access_token": "access-token",
+		"expires_in":   3600,
+	})
+	assertEmptyDir(c, home)
+}
+
+func TestLogin_OnceCSV(t *testing.T) {
+	c := qt.New(t)
+	home := setupLoginOnce(c)
+
+	var buf bytes.Buffer
+	format := printer.CSV
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config:  &config.Config{},
+	}
+
+	cmd := LoginCmd(ch)
+	cmd.SetArgs([]string{"--once"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.Equals, "access_token,expires_in\naccess-token,3600\n\n")
+	assertEmptyDir(c, home)
+}
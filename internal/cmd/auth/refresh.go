@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/planetscale/cli/internal/auth"
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/config"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RefreshCmd is the command for renewing the access token with the stored
+// refresh token.
+func RefreshCmd(ch *cmdutil.Helper) *cobra.Command {
+	var clientID string
+	var clientSecret string
+	var authURL string
+
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Args:  cobra.NoArgs,
+		Short: "Renew the access token without logging in again",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			refreshToken, err := readRefreshToken()
+			if err != nil {
+				return err
+			}
+
+			if refreshToken == "" {
+				ch.Printer.Println("No refresh token is stored, logging in again instead.")
+				return login(ctx, ch, clientID, clientSecret, authURL)
+			}
+
+			authenticator, err := auth.New(cleanhttp.DefaultClient(), clientID, clientSecret, auth.SetBaseURL(authURL))
+			if err != nil {
+				return err
+			}
+
+			end := ch.Printer.PrintProgress("Refreshing access token...")
+			defer end()
+
+			tokens, err := authenticator.RefreshAccessToken(ctx, refreshToken, clientID)
+			if err != nil {
+				return errors.Wrap(err, "error refreshing access token, run 'pscale auth login' to log in again")
+			}
+
+			err = writeAccessToken(ctx, tokens.AccessToken)
+			if err != nil {
+				return errors.Wrap(err, "error refreshing access token")
+			}
+
+			// the server may rotate the refresh token, which invalidates the
+			// stored one.
+			if tokens.RefreshToken != "" && tokens.RefreshToken != refreshToken {
+				err = writeRefreshToken(ctx, tokens.RefreshToken)
+				if err != nil {
+					return errors.Wrap(err, "error refreshing access token")
+				}
+			}
+
+			end()
+			ch.Printer.Println("Successfully refreshed the access token.")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&clientID, "client-id", auth.OAuthClientID, "The client ID for the PlanetScale CLI application.")
+	cmd.Flags().StringVar(&clientSecret, "client-secret", auth.OAuthClientSecret, "The client ID for the PlanetScale CLI application")
+	cmd.Flags().StringVar(&authURL, "api-url", auth.DefaultBaseURL, "The PlanetScale Auth API base URL.")
+
+	return cmd
+}
+
+// readRefreshToken returns the stored refresh token, or an empty string if
+// none was stored.
+func readRefreshToken() (string, error) {
+	tokenPath, err := config.RefreshTokenPath()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := ioutil.ReadFile(tokenPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "error reading refresh token")
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/config"
+	"github.com/planetscale/cli/internal/printer"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/mitchellh/go-homedir"
+)
+
+func TestRefresh_RotatedRefreshToken(t *testing.T) {
+	c := qt.New(t)
+
+	home := c.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", home)
+	homedir.DisableCache = true
+	c.Cleanup(func() {
+		os.Setenv("HOME", oldHome)
+		homedir.DisableCache = false
+	})
+
+	refreshTokenPath, err := config.RefreshTokenPath()
+	c.Assert(err, qt.IsNil)
+	c.Assert(os.MkdirAll(filepath.Dir(refreshTokenPath), 0771), qt.IsNil)
+	c.Assert(ioutil.WriteFile(refreshTokenPath, []byte("old-refresh-token"), config.TokenFileMode), qt.IsNil)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Path, qt.Equals, "/oauth/token")
+		c.Check(r.FormValue("refresh_token"), qt.Equals, "old-refresh-token")
+		_, _ = w.Write([]byte(`{"access_token": "new-access-token", "refresh_token": "new-refresh-token"}`))
+	}))
+	defer srv.Close()
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(ioutil.Discard)
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config:  &config.Config{},
+	}
+
+	cmd := RefreshCmd(ch)
+	cmd.SetArgs([]string{"--api-url", srv.URL})
+	err = cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	accessTokenPath, err := config.AccessTokenPath()
+	c.Assert(err, qt.IsNil)
+
+	accessToken, err := ioutil.ReadFile(accessTokenPath)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(accessToken), qt.Equals, "new-access-token")
+
+	refreshToken, err := ioutil.ReadFile(refreshTokenPath)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(refreshToken), qt.Equals, "new-refresh-token")
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+
+	"github.com/spf13/cobra"
+)
+
+// StatusCmd is the command for showing the current authentication status.
+func StatusCmd(ch *cmdutil.Helper) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Args:  cobra.NoArgs,
+		Short: "Show the credentials the CLI is currently using",
+		Long: `Show the credentials the CLI is currently using. The API has no identity
+endpoint, so the details of an access token are read from its claims and
+aren't verified. Use 'pscale auth login --check' to verify them.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !ch.Config.IsAuthenticated() {
+				return &cmdutil.Error{
+					Msg:      "not logged in, run 'pscale auth login' to authenticate",
+					ExitCode: 1,
+				}
+			}
+
+			status := currentStatus(ch, time.Now())
+			if err := ch.Printer.PrintResource(status); err != nil {
+				return err
+			}
+
+			if status.Expired {
+				return &cmdutil.Error{
+					Msg:      "the access token has expired, run 'pscale auth refresh' or 'pscale auth login' to authenticate again",
+					ExitCode: 1,
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// authStatus is the printable authentication status.
+type authStatus struct {
+	TokenType        string `header:"token type" json:"token_type"`
+	ServiceTokenName string `header:"service token,-" json:"service_token_name,omitempty"`
+	Subject          string `header:"subject,-" json:"subject,omitempty"`
+	Email            string `header:"email,-" json:"email,omitempty"`
+	ExpiresAt        *int64 `header:"expires_at,timestamp(ms|utc|human),-" json:"expires_at,omitempty"`
+	Expired          bool   `header:"expired" json:"expired"`
+}
+
+func (a *authStatus) MarshalCSVValue() interface{} {
+	return []*authStatus{a}
+}
+
+// tokenClaims are the JWT claims we're interested in.
+type tokenClaims struct {
+	Subject   string `json:"sub"`
+	Email     string `json:"email"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// currentStatus returns the status of the credentials in the config. Service
+// tokens take precedence, just like when creating the API client.
+func currentStatus(ch *cmdutil.Helper, now time.Time) *authStatus {
+	if ch.Config.ServiceToken != "" && ch.Config.ServiceTokenName != "" {
+		return &authStatus{
+			TokenType:        "service token",
+			ServiceTokenName: ch.Config.ServiceTokenName,
+		}
+	}
+
+	status := &authStatus{TokenType: "oauth"}
+
+	claims, err := decodeClaims(ch.Config.AccessToken)
+	if err != nil {
+		// not every access token is a JWT, in which case there is nothing
+		// more to show without calling the API.
+		return status
+	}
+
+	status.Subject = claims.Subject
+	status.Email = claims.Email
+	if claims.ExpiresAt != 0 {
+		expiresAt := time.Unix(claims.ExpiresAt, 0)
+		status.ExpiresAt = printer.GetMillisecondsIfExists(&expiresAt)
+		status.Expired = expiresAt.Before(now)
+	}
+
+	return status
+}
+
+// decodeClaims decodes the claims of a JWT without verifying its signature.
+func decodeClaims(token string) (*tokenClaims, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, errors.New("access token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, err
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
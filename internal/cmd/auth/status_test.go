@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/config"
+	"github.com/planetscale/cli/internal/printer"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func testToken(claims string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"RS256"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".signature"
+}
+
+func TestStatusCmd(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	exp := time.Now().Add(time.Hour).Unix()
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			AccessToken: testToken(fmt.Sprintf(`{"sub":"user-1","email":"dev@example.com","exp":%d}`, exp)),
+		},
+	}
+
+	cmd := StatusCmd(ch)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	expiresAt := exp * 1000
+	res := &authStatus{
+		TokenType: "oauth",
+		Subject:   "user-1",
+		Email:     "dev@example.com",
+		ExpiresAt: &expiresAt,
+	}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestStatusCmd_Expired(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	exp := time.Now().Add(-time.Hour).Unix()
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			AccessToken: testToken(fmt.Sprintf(`{"sub":"user-1","exp":%d}`, exp)),
+		},
+	}
+
+	cmd := StatusCmd(ch)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	c.Assert(err, qt.ErrorMatches, "the access token has expired.*")
+
+	expiresAt := exp * 1000
+	res := &authStatus{
+		TokenType: "oauth",
+		Subject:   "user-1",
+		ExpiresAt: &expiresAt,
+		Expired:   true,
+	}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestStatusCmd_ServiceToken(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			ServiceTokenName: "ci",
+			ServiceToken:     "secret",
+		},
+	}
+
+	cmd := StatusCmd(ch)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	res := &authStatus{
+		TokenType:        "service token",
+		ServiceTokenName: "ci",
+	}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
@@ -1,7 +1,9 @@
 package backup
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
@@ -12,11 +14,17 @@ import (
 
 func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 	createReq := &ps.CreateBackupRequest{}
+	var flags struct {
+		wait        bool
+		waitTimeout time.Duration
+	}
+
 	cmd := &cobra.Command{
-		Use:     "create <database> <branch>",
-		Short:   "Backup a branch's data and schema",
-		Args:    cmdutil.RequiredArgs("database", "branch"),
-		Aliases: []string{"b"},
+		Use:               "create <database> <branch>",
+		Short:             "Backup a branch's data and schema",
+		Args:              cmdutil.RequiredArgs("database", "branch"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		Aliases:           []string{"b"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
@@ -45,9 +53,36 @@ func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 				}
 			}
 
+			if flags.wait {
+				bkp, err = waitBackupDone(ctx, client, &ps.GetBackupRequest{
+					Organization: ch.Config.Organization,
+					Database:     database,
+					Branch:       branch,
+					Backup:       bkp.PublicID,
+				}, flags.waitTimeout)
+				if err != nil {
+					return err
+				}
+			}
+
 			end()
 
-			if ch.Printer.Format() == printer.Human {
+			if flags.wait && bkp.State != "success" {
+				if !ch.Printer.IsHuman() {
+					if err := ch.Printer.PrintResource(toBackup(bkp)); err != nil {
+						return err
+					}
+				}
+				return fmt.Errorf("backup %s of %s failed with state %s",
+					printer.BoldBlue(bkp.Name), printer.BoldBlue(branch), printer.BoldRed(bkp.State))
+			}
+
+			if ch.Printer.IsHuman() {
+				if flags.wait {
+					ch.Printer.Printf("Backup %s was successfully completed.\n\n", printer.BoldBlue(bkp.Name))
+					ch.Printer.Print(backupDetails(bkp))
+					return nil
+				}
 				ch.Printer.Printf("Backup %s was successfully created.\n", printer.BoldBlue(bkp.Name))
 				return nil
 			}
@@ -56,5 +91,41 @@ func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVar(&flags.wait, "wait", false, "Wait until the backup is completed")
+	cmd.Flags().DurationVar(&flags.waitTimeout, "wait-timeout", time.Hour, "How long to wait for the backup to complete")
+
 	return cmd
 }
+
+// backupPollInterval is how often a backup is checked while waiting for it
+// to complete.
+var backupPollInterval = 5 * time.Second
+
+// waitBackupDone polls the backup until its state is success or error.
+func waitBackupDone(ctx context.Context, client *ps.Client, getReq *ps.GetBackupRequest, timeout time.Duration) (*ps.Backup, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(backupPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for backup %s to complete", printer.BoldBlue(getReq.Backup))
+		case <-ticker.C:
+			b, err := client.Backups.Get(ctx, getReq)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, fmt.Errorf("timed out waiting for backup %s to complete", printer.BoldBlue(getReq.Backup))
+				}
+				return nil, cmdutil.HandleError(err)
+			}
+
+			switch b.State {
+			case "success", "error":
+				return b, nil
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/config"
@@ -59,3 +60,97 @@ func TestBackup_CreateCmd(t *testing.T) {
 	c.Assert(svc.CreateFnInvoked, qt.IsTrue)
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestBackup_CreateCmd_Wait(t *testing.T) {
+	c := qt.New(t)
+
+	old := backupPollInterval
+	backupPollInterval = time.Millisecond
+	c.Cleanup(func() { backupPollInterval = old })
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	res := &ps.Backup{PublicID: "abc", Name: "foo", State: "success", Size: 1024}
+
+	gets := 0
+	svc := &mock.BackupsService{
+		CreateFn: func(ctx context.Context, req *ps.CreateBackupRequest) (*ps.Backup, error) {
+			return &ps.Backup{PublicID: "abc", Name: "foo", State: "pending"}, nil
+		},
+		GetFn: func(ctx context.Context, req *ps.GetBackupRequest) (*ps.Backup, error) {
+			c.Assert(req.Backup, qt.Equals, "abc")
+			gets++
+			if gets < 3 {
+				return &ps.Backup{PublicID: "abc", Name: "foo", State: "running"}, nil
+			}
+			return res, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Backups: svc,
+			}, nil
+		},
+	}
+
+	cmd := CreateCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "development", "--wait"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(gets, qt.Equals, 3)
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestBackup_CreateCmd_WaitError(t *testing.T) {
+	c := qt.New(t)
+
+	old := backupPollInterval
+	backupPollInterval = time.Millisecond
+	c.Cleanup(func() { backupPollInterval = old })
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	res := &ps.Backup{PublicID: "abc", Name: "foo", State: "error"}
+
+	svc := &mock.BackupsService{
+		CreateFn: func(ctx context.Context, req *ps.CreateBackupRequest) (*ps.Backup, error) {
+			return &ps.Backup{PublicID: "abc", Name: "foo", State: "pending"}, nil
+		},
+		GetFn: func(ctx context.Context, req *ps.GetBackupRequest) (*ps.Backup, error) {
+			return res, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Backups: svc,
+			}, nil
+		},
+	}
+
+	cmd := CreateCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "development", "--wait"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "backup foo of development failed with state error")
+	c.Assert(svc.GetFnInvoked, qt.IsTrue)
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
@@ -1,7 +1,9 @@
 package backup
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
@@ -13,12 +15,40 @@ import (
 
 // ListCmd encapsulates the command for listing backups for a branch.
 func ListCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		expired        bool
+		expiringWithin time.Duration
+		onlyRestorable bool
+		jsonSchema     bool
+		page           cmdutil.Pagination
+	}
+
 	cmd := &cobra.Command{
-		Use:     "list <database> <branch>",
-		Short:   "List all backups of a branch",
-		Args:    cmdutil.RequiredArgs("database", "branch"),
-		Aliases: []string{"ls"},
+		Use:   "list <database> <branch>",
+		Short: "List all backups of a branch",
+		Args: func(cmd *cobra.Command, args []string) error {
+			// the schema is the same for every branch
+			if flags.jsonSchema {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cmdutil.RequiredArgs("database", "branch")(cmd, args)
+		},
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		Aliases:           []string{"ls"},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return flags.page.Validate(cmd, ch.Printer.IsHuman())
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.jsonSchema {
+				out, err := json.MarshalIndent(printer.JSONSchema(&planetscale.Backup{}), "", "  ")
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+
 			ctx := cmd.Context()
 			database := args[0]
 			branch := args[1]
@@ -29,7 +59,7 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 			}
 
 			if web {
-				fmt.Println("🌐  Redirecting you to your backups in your web browser.")
+				ch.Printer.Println("🌐  Redirecting you to your backups in your web browser.")
 				err := browser.OpenURL(fmt.Sprintf("%s/%s/%s/%s/backups", cmdutil.ApplicationURL, ch.Config.Organization, database, branch))
 				if err != nil {
 					return err
@@ -60,15 +90,97 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 			}
 			end()
 
-			if len(backups) == 0 && ch.Printer.Format() == printer.Human {
+			if flags.onlyRestorable {
+				backups = filterRestorable(backups, time.Now())
+			}
+
+			filtered := flags.expired || flags.expiringWithin > 0
+			if filtered {
+				backups = filterExpiring(backups, time.Now(), flags.expired, flags.expiringWithin)
+			}
+
+			if len(backups) == 0 && ch.Printer.IsHuman() {
+				if filtered {
+					ch.Printer.Printf("No expired or expiring backups exist in %s.\n", printer.BoldBlue(branch))
+					return nil
+				}
+
+				if flags.onlyRestorable {
+					ch.Printer.Printf("No restorable backups exist in %s.\n", printer.BoldBlue(branch))
+					return nil
+				}
+
 				ch.Printer.Printf("No backups exist in %s.\n", printer.BoldBlue(branch))
 				return nil
 			}
 
-			return ch.Printer.PrintResource(toBackups(backups))
+			first, last := flags.page.Bounds(len(backups))
+			bs := toBackups(backups[first:last])
+			if filtered && ch.Printer.IsHuman() {
+				markExpiring(bs, time.Now())
+			}
+
+			if err := ch.Printer.PrintResource(bs); err != nil {
+				return err
+			}
+
+			ch.Printer.Print(flags.page.Footer(len(backups)))
+			return nil
 		},
 	}
 
 	cmd.Flags().BoolP("web", "w", false, "List backups in your web browser.")
+	cmd.Flags().BoolVar(&flags.expired, "expired", false, "Only list backups that have already expired.")
+	cmd.Flags().DurationVar(&flags.expiringWithin, "expiring-within", 0,
+		"Only list backups that expire within the given duration, i.e: 72h.")
+	cmd.Flags().BoolVar(&flags.onlyRestorable, "only-restorable", false,
+		"Only list backups that succeeded and haven't expired yet.")
+	cmd.Flags().BoolVar(&flags.jsonSchema, "output-json-schema", false,
+		"Print the JSON Schema of a backup in the JSON output instead of listing backups. Takes no arguments.")
+	flags.page.AddFlags(cmd)
 	return cmd
 }
+
+// filterExpiring returns the backups that are expired (if expired is true) or
+// that expire within the given window, relative to now.
+func filterExpiring(backups []*planetscale.Backup, now time.Time, expired bool, within time.Duration) []*planetscale.Backup {
+	filtered := make([]*planetscale.Backup, 0, len(backups))
+	for _, b := range backups {
+		isExpired := b.ExpiresAt.Before(now)
+		if expired && isExpired {
+			filtered = append(filtered, b)
+			continue
+		}
+
+		if within > 0 && !isExpired && b.ExpiresAt.Before(now.Add(within)) {
+			filtered = append(filtered, b)
+		}
+	}
+
+	return filtered
+}
+
+// filterRestorable returns the backups that completed successfully and
+// haven't expired at the given time.
+func filterRestorable(backups []*planetscale.Backup, now time.Time) []*planetscale.Backup {
+	filtered := make([]*planetscale.Backup, 0, len(backups))
+	for _, b := range backups {
+		if b.State == "success" && b.ExpiresAt.After(now) {
+			filtered = append(filtered, b)
+		}
+	}
+
+	return filtered
+}
+
+// markExpiring appends an [EXPIRED] or [EXPIRING] indicator to the state of
+// each backup. It only affects the human readable output.
+func markExpiring(backups []*Backup, now time.Time) {
+	for _, b := range backups {
+		if b.orig.ExpiresAt.Before(now) {
+			b.State = fmt.Sprintf("%s [EXPIRED]", b.State)
+		} else {
+			b.State = fmt.Sprintf("%s [EXPIRING]", b.State)
+		}
+	}
+}
@@ -3,7 +3,9 @@ package backup
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/config"
@@ -74,3 +76,149 @@ func TestBackup_ListCmd(t *testing.T) {
 
 	c.Assert(buf.String(), qt.JSONEquals, backups)
 }
+
+func TestBackup_ListCmd_Expired(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	branch := "development"
+
+	now := time.Now()
+	resp := []*ps.Backup{
+		{Name: "expired", ExpiresAt: now.Add(-time.Hour)},
+		{Name: "expiring", ExpiresAt: now.Add(time.Hour)},
+		{Name: "fresh", ExpiresAt: now.Add(72 * time.Hour)},
+	}
+
+	svc := &mock.BackupsService{
+		ListFn: func(ctx context.Context, req *ps.ListBackupsRequest) ([]*ps.Backup, error) {
+			return resp, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Backups: svc,
+			}, nil
+
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{db, branch, "--expired", "--expiring-within", "24h"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListFnInvoked, qt.IsTrue)
+
+	backups := []*Backup{
+		{orig: resp[0]},
+		{orig: resp[1]},
+	}
+
+	c.Assert(buf.String(), qt.JSONEquals, backups)
+}
+
+func TestBackup_ListCmd_OnlyRestorable(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	branch := "development"
+
+	now := time.Now()
+	resp := []*ps.Backup{
+		{Name: "expired", State: "success", ExpiresAt: now.Add(-time.Hour)},
+		{Name: "failed", State: "error", ExpiresAt: now.Add(time.Hour)},
+		{Name: "restorable", State: "success", ExpiresAt: now.Add(time.Hour)},
+	}
+
+	svc := &mock.BackupsService{
+		ListFn: func(ctx context.Context, req *ps.ListBackupsRequest) ([]*ps.Backup, error) {
+			return resp, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Backups: svc,
+			}, nil
+
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{db, branch, "--only-restorable"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListFnInvoked, qt.IsTrue)
+
+	backups := []*Backup{
+		{orig: resp[2]},
+	}
+
+	c.Assert(buf.String(), qt.JSONEquals, backups)
+}
+
+func TestBackup_ListCmd_JSONSchema(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+
+	svc := &mock.BackupsService{}
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Backups: svc,
+			}, nil
+		},
+	}
+
+	var buf bytes.Buffer
+	cmd := ListCmd(ch)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--output-json-schema"})
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListFnInvoked, qt.IsFalse)
+
+	var schema struct {
+		Title      string                            `json:"title"`
+		Properties map[string]map[string]interface{} `json:"properties"`
+		Required   []string                          `json:"required"`
+	}
+	err = json.Unmarshal(buf.Bytes(), &schema)
+	c.Assert(err, qt.IsNil)
+	c.Assert(schema.Title, qt.Equals, "Backup")
+	c.Assert(schema.Properties["id"]["type"], qt.Equals, "string")
+	c.Assert(schema.Properties["size"]["type"], qt.Equals, "integer")
+	c.Assert(schema.Properties["created_at"]["format"], qt.Equals, "date-time")
+	c.Assert(schema.Required, qt.Contains, "state")
+}
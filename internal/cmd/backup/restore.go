@@ -14,9 +14,10 @@ import (
 
 func RestoreCmd(ch *cmdutil.Helper) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "restore <database> <branch> <backup>",
-		Short: "Restore a backup to a new branch",
-		Args:  cmdutil.RequiredArgs("database", "branch", "backup"),
+		Use:               "restore <database> <branch> <backup>",
+		Short:             "Restore a backup to a new branch",
+		Args:              cmdutil.RequiredArgs("database", "branch", "backup"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
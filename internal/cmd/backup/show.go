@@ -2,6 +2,9 @@ package backup
 
 import (
 	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
@@ -14,9 +17,10 @@ import (
 
 func ShowCmd(ch *cmdutil.Helper) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "show <database> <branch> <backup>",
-		Short: "Show a specific backup of a branch",
-		Args:  cmdutil.RequiredArgs("database", "branch", "backup"),
+		Use:               "show <database> <branch> <backup>",
+		Short:             "Show a specific backup of a branch",
+		Args:              cmdutil.RequiredArgs("database", "branch", "backup"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
@@ -62,6 +66,11 @@ func ShowCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			end()
 
+			if ch.Printer.IsHuman() {
+				ch.Printer.Print(backupDetails(bkp))
+				return nil
+			}
+
 			return ch.Printer.PrintResource(toBackup(bkp))
 		},
 	}
@@ -69,3 +78,28 @@ func ShowCmd(ch *cmdutil.Helper) *cobra.Command {
 	cmd.Flags().BoolP("web", "w", false, "Show a branch backup in your web browser.")
 	return cmd
 }
+
+// backupDetails returns the human readable details of a single backup, one
+// field per line.
+func backupDetails(bkp *planetscale.Backup) string {
+	timestamp := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.UTC().Format("2006-01-02 15:04:05 MST")
+	}
+
+	var buf strings.Builder
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("ID:"), bkp.PublicID)
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Name:"), bkp.Name)
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("State:"), bkp.State)
+	fmt.Fprintf(w, "%s\t%d bytes\n", printer.Bold("Size:"), bkp.Size)
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Created at:"), timestamp(bkp.CreatedAt))
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Started at:"), timestamp(bkp.StartedAt))
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Completed at:"), timestamp(bkp.CompletedAt))
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Expires at:"), timestamp(bkp.ExpiresAt))
+	w.Flush() // nolint:errcheck
+
+	return buf.String()
+}
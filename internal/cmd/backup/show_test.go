@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/config"
@@ -61,3 +62,50 @@ func TestBackup_ShowCmd(t *testing.T) {
 	c.Assert(svc.GetFnInvoked, qt.IsTrue)
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestBackup_ShowCmd_Human(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&buf)
+
+	createdAt := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	res := &ps.Backup{
+		PublicID:  "abc123",
+		Name:      "mybackup",
+		State:     "success",
+		Size:      1024,
+		CreatedAt: createdAt,
+		ExpiresAt: createdAt.Add(24 * time.Hour),
+	}
+
+	svc := &mock.BackupsService{
+		GetFn: func(ctx context.Context, req *ps.GetBackupRequest) (*ps.Backup, error) {
+			return res, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Backups: svc,
+			}, nil
+		},
+	}
+
+	cmd := ShowCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "development", "mybackup"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.Contains, "mybackup")
+	c.Assert(buf.String(), qt.Contains, "1024 bytes")
+	c.Assert(buf.String(), qt.Contains, "2021-09-01 10:00:00 UTC")
+	c.Assert(buf.String(), qt.Contains, "2021-09-02 10:00:00 UTC")
+}
@@ -15,44 +15,11 @@ func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 	createReq := &ps.CreateDatabaseBranchRequest{}
 
 	cmd := &cobra.Command{
-		Use:     "create <source-database> <branch> [options]",
-		Short:   "Create a new branch from a database",
-		Args:    cmdutil.RequiredArgs("source-database", "branch"),
-		Aliases: []string{"b"},
-		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-			if len(args) != 0 {
-				return nil, cobra.ShellCompDirectiveNoFileComp
-			}
-
-			client, err := ch.Client()
-			if err != nil {
-				return nil, cobra.ShellCompDirectiveNoFileComp
-			}
-
-			org := ch.Config.Organization // --org flag
-			if org == "" {
-				cfg, err := ch.ConfigFS.DefaultConfig()
-				if err != nil {
-					return nil, cobra.ShellCompDirectiveNoFileComp
-				}
-
-				org = cfg.Organization
-			}
-
-			databases, err := client.Databases.List(cmd.Context(), &ps.ListDatabasesRequest{
-				Organization: org,
-			})
-			if err != nil {
-				return nil, cobra.ShellCompDirectiveNoFileComp
-			}
-
-			candidates := make([]string, 0, len(databases))
-			for _, db := range databases {
-				candidates = append(candidates, db.Name)
-			}
-
-			return candidates, cobra.ShellCompDirectiveNoFileComp
-		},
+		Use:               "create <source-database> <branch> [options]",
+		Short:             "Create a new branch from a database",
+		Args:              cmdutil.RequiredArgs("source-database", "branch"),
+		Aliases:           []string{"b"},
+		ValidArgsFunction: cmdutil.DatabaseCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			source := args[0]
 			branch := args[1]
@@ -98,7 +65,7 @@ func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			end()
 
-			if ch.Printer.Format() == printer.Human {
+			if ch.Printer.IsHuman() {
 				ch.Printer.Printf("Branch %s was successfully created.\n", printer.BoldBlue(dbBranch.Name))
 				return nil
 			}
@@ -18,10 +18,11 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 	var force bool
 
 	cmd := &cobra.Command{
-		Use:     "delete <database> <branch>",
-		Short:   "Delete a branch from a database",
-		Args:    cmdutil.RequiredArgs("database", "branch"),
-		Aliases: []string{"rm"},
+		Use:               "delete <database> <branch>",
+		Short:             "Delete a branch from a database",
+		Args:              cmdutil.RequiredArgs("database", "branch"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		Aliases:           []string{"rm"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			source := args[0]
@@ -33,7 +34,7 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 			}
 
 			if !force {
-				if ch.Printer.Format() != printer.Human {
+				if !ch.Printer.IsHuman() {
 					return fmt.Errorf("cannot delete branch with the output format %q (run with -force to override)", ch.Printer.Format())
 				}
 
@@ -99,7 +100,7 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			end()
 
-			if ch.Printer.Format() == printer.Human {
+			if ch.Printer.IsHuman() {
 				ch.Printer.Printf("Branch %s was successfully deleted from %s.\n", printer.BoldBlue(branch), printer.BoldBlue(source))
 				return nil
 			}
@@ -2,10 +2,13 @@ package branch
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/pkg/browser"
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
 	"github.com/planetscale/planetscale-go/planetscale"
@@ -20,57 +23,69 @@ func DiffCmd(ch *cmdutil.Helper) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "diff <database> <branch>",
-		Short: "Show the diff of a branch",
-		Args:  cmdutil.RequiredArgs("database", "branch"),
+		Use:   "diff <database> <branch> [head-branch]",
+		Short: "Show the diff of a branch, or the schema diff between two branches",
+		Long: `Show the diff of a branch against its parent. If a head branch is given, show
+the schema changes that head-branch has compared to branch.`,
+		Args:              cmdutil.RequiredArgs("database", "branch"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database, branch := args[0], args[1]
 
+			var head string
+			if len(args) == 3 {
+				head = args[2]
+			}
+
+			if flags.web {
+				target := branch
+				if head != "" {
+					target = head
+				}
+
+				ch.Printer.Println("🌐  Redirecting you to your branch diff in your web browser.")
+				return browser.OpenURL(fmt.Sprintf("%s/%s/%s/branches/%s", cmdutil.ApplicationURL, ch.Config.Organization, database, target))
+			}
+
 			client, err := ch.Client()
 			if err != nil {
 				return err
 			}
 
-			diffs, err := client.DatabaseBranches.Diff(ctx, &planetscale.DiffBranchRequest{
-				Organization: ch.Config.Organization,
-				Database:     database,
-				Branch:       branch,
-			})
-			if err != nil {
-				switch cmdutil.ErrCode(err) {
-				case planetscale.ErrNotFound:
-					return fmt.Errorf("branch %s does not exist in database %s (organization: %s)",
-						printer.BoldBlue(branch), printer.BoldBlue(database), printer.BoldBlue(ch.Config.Organization))
-				default:
-					return cmdutil.HandleError(err)
+			var diffs []*planetscale.Diff
+			if head != "" {
+				diffs, err = diffBranches(ctx, ch, client, database, branch, head)
+				if err != nil {
+					return err
+				}
+			} else {
+				diffs, err = client.DatabaseBranches.Diff(ctx, &planetscale.DiffBranchRequest{
+					Organization: ch.Config.Organization,
+					Database:     database,
+					Branch:       branch,
+				})
+				if err != nil {
+					switch cmdutil.ErrCode(err) {
+					case planetscale.ErrNotFound:
+						return fmt.Errorf("branch %s does not exist in database %s (organization: %s)",
+							printer.BoldBlue(branch), printer.BoldBlue(database), printer.BoldBlue(ch.Config.Organization))
+					default:
+						return cmdutil.HandleError(err)
+					}
 				}
 			}
 
-			if ch.Printer.Format() != printer.Human {
+			if !ch.Printer.IsHuman() {
 				return ch.Printer.PrintResource(diffs)
 			}
 
-			// human readable output
-			for _, df := range diffs {
-				ch.Printer.Println("--", printer.BoldBlue(df.Name), "--")
-				scanner := bufio.NewScanner(strings.NewReader(strings.TrimSpace(df.Raw)))
-				for scanner.Scan() {
-					txt := scanner.Text()
-					if strings.HasPrefix(txt, "+") {
-						ch.Printer.Println(color.New(color.FgGreen).Add(color.Bold).Sprint(txt)) //nolint: errcheck
-					} else if strings.HasPrefix(txt, "-") {
-						ch.Printer.Println(color.New(color.FgRed).Add(color.Bold).Sprint(txt)) //nolint: errcheck
-					} else {
-						ch.Printer.Println(txt)
-					}
-				}
-				if err := scanner.Err(); err != nil {
-					return fmt.Errorf("reading diff raw: %s", err)
-				}
+			if head != "" && len(diffs) == 0 {
+				ch.Printer.Printf("Branches %s and %s have the same schema.\n", printer.BoldBlue(branch), printer.BoldBlue(head))
+				return nil
 			}
 
-			return nil
+			return printDiffs(ch, diffs)
 		},
 	}
 
@@ -78,3 +93,153 @@ func DiffCmd(ch *cmdutil.Helper) *cobra.Command {
 
 	return cmd
 }
+
+// diffBranches fetches the schemas of the base and head branches and returns
+// a diff for every table that differs between them.
+func diffBranches(ctx context.Context, ch *cmdutil.Helper, client *planetscale.Client, database, base, head string) ([]*planetscale.Diff, error) {
+	baseSchema, err := branchSchema(ctx, ch, client, database, base)
+	if err != nil {
+		return nil, err
+	}
+
+	headSchema, err := branchSchema(ctx, ch, client, database, head)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffSchemas(baseSchema, headSchema), nil
+}
+
+// branchSchema returns the schema of every table of the branch, keyed by the
+// table name.
+func branchSchema(ctx context.Context, ch *cmdutil.Helper, client *planetscale.Client, database, branch string) (map[string]string, error) {
+	tables, err := client.DatabaseBranches.Schema(ctx, &planetscale.BranchSchemaRequest{
+		Organization: ch.Config.Organization,
+		Database:     database,
+		Branch:       branch,
+	})
+	if err != nil {
+		switch cmdutil.ErrCode(err) {
+		case planetscale.ErrNotFound:
+			return nil, fmt.Errorf("branch %s does not exist in database %s (organization: %s)",
+				printer.BoldBlue(branch), printer.BoldBlue(database), printer.BoldBlue(ch.Config.Organization))
+		default:
+			return nil, cmdutil.HandleError(err)
+		}
+	}
+
+	out := make(map[string]string, len(tables))
+	for _, t := range tables {
+		out[t.Name] = strings.TrimSpace(t.Raw)
+	}
+	return out, nil
+}
+
+// diffSchemas returns a diff for every table that differs between the base
+// and head schemas, sorted by the table name.
+func diffSchemas(baseSchema, headSchema map[string]string) []*planetscale.Diff {
+	names := make([]string, 0, len(baseSchema)+len(headSchema))
+	for name := range baseSchema {
+		names = append(names, name)
+	}
+	for name := range headSchema {
+		if _, ok := baseSchema[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diffs []*planetscale.Diff
+	for _, name := range names {
+		from, to := baseSchema[name], headSchema[name]
+		if from == to {
+			continue
+		}
+
+		diffs = append(diffs, &planetscale.Diff{
+			Name: name,
+			Raw:  strings.Join(diffLines(splitLines(from), splitLines(to)), "\n"),
+		})
+	}
+
+	return diffs
+}
+
+// printDiffs prints the diffs in a human readable format, with the added
+// lines in green and the removed ones in red.
+func printDiffs(ch *cmdutil.Helper, diffs []*planetscale.Diff) error {
+	for _, df := range diffs {
+		ch.Printer.Println("--", printer.BoldBlue(df.Name), "--")
+		scanner := bufio.NewScanner(strings.NewReader(strings.TrimSpace(df.Raw)))
+		for scanner.Scan() {
+			txt := scanner.Text()
+			if strings.HasPrefix(txt, "+") {
+				ch.Printer.Println(color.New(color.FgGreen).Add(color.Bold).Sprint(txt)) //nolint: errcheck
+			} else if strings.HasPrefix(txt, "-") {
+				ch.Printer.Println(color.New(color.FgRed).Add(color.Bold).Sprint(txt)) //nolint: errcheck
+			} else {
+				ch.Printer.Println(txt)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("reading diff raw: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// diffLines returns the lines of a and b prefixed with "-" if they're only in
+// a, "+" if they're only in b and " " if they're in both, based on their
+// longest common subsequence.
+func diffLines(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and
+	// b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	out := make([]string, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, " "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "-"+a[i])
+			i++
+		default:
+			out = append(out, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		out = append(out, "+"+b[j])
+	}
+
+	return out
+}
@@ -63,3 +63,88 @@ func TestBranchDiffCmd(t *testing.T) {
 
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestBranchDiffCmd_HeadBranch(t *testing.T) {
+	c := qt.New(t)
+
+	org := "planetscale"
+	db := "planetscale"
+
+	schemas := map[string][]*ps.Diff{
+		"main": {
+			{Name: "users", Raw: "CREATE TABLE `users` (\n  `id` int,\n  PRIMARY KEY (`id`)\n)"},
+			{Name: "old", Raw: "CREATE TABLE `old` (\n  `id` int\n)"},
+		},
+		"feature": {
+			{Name: "users", Raw: "CREATE TABLE `users` (\n  `id` int,\n  `email` varchar(255),\n  PRIMARY KEY (`id`)\n)"},
+		},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		SchemaFn: func(ctx context.Context, req *ps.BranchSchemaRequest) ([]*ps.Diff, error) {
+			c.Assert(req.Organization, qt.Equals, org)
+			c.Assert(req.Database, qt.Equals, db)
+
+			return schemas[req.Branch], nil
+		},
+	}
+
+	want := []*ps.Diff{
+		{Name: "old", Raw: "-CREATE TABLE `old` (\n-  `id` int\n-)"},
+		{Name: "users", Raw: " CREATE TABLE `users` (\n   `id` int,\n+  `email` varchar(255),\n   PRIMARY KEY (`id`)\n )"},
+	}
+
+	c.Run("json", func(c *qt.C) {
+		var buf bytes.Buffer
+		format := printer.JSON
+		p := printer.NewPrinter(&format)
+		p.SetResourceOutput(&buf)
+
+		ch := &cmdutil.Helper{
+			Printer: p,
+			Config: &config.Config{
+				Organization: org,
+			},
+			Client: func() (*ps.Client, error) {
+				return &ps.Client{
+					DatabaseBranches: svc,
+				}, nil
+			},
+		}
+
+		cmd := DiffCmd(ch)
+		cmd.SetArgs([]string{db, "main", "feature"})
+		err := cmd.Execute()
+
+		c.Assert(err, qt.IsNil)
+		c.Assert(svc.SchemaFnInvoked, qt.IsTrue)
+		c.Assert(buf.String(), qt.JSONEquals, want)
+	})
+
+	c.Run("human", func(c *qt.C) {
+		var buf bytes.Buffer
+		format := printer.Human
+		p := printer.NewPrinter(&format)
+		p.SetHumanOutput(&buf)
+
+		ch := &cmdutil.Helper{
+			Printer: p,
+			Config: &config.Config{
+				Organization: org,
+			},
+			Client: func() (*ps.Client, error) {
+				return &ps.Client{
+					DatabaseBranches: svc,
+				}, nil
+			},
+		}
+
+		cmd := DiffCmd(ch)
+		cmd.SetArgs([]string{db, "main", "feature"})
+		err := cmd.Execute()
+
+		c.Assert(err, qt.IsNil)
+		c.Assert(buf.String(), qt.Contains, "+  `email` varchar(255),")
+		c.Assert(buf.String(), qt.Contains, "-CREATE TABLE `old` (")
+	})
+}
@@ -1,7 +1,11 @@
 package branch
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
@@ -13,11 +17,49 @@ import (
 
 // ListCmd encapsulates the command for listing branches for a database.
 func ListCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		withOpenDeployRequests bool
+		hasOpenDeployRequest   bool
+		exclude                []string
+		productionOnly         bool
+		noProduction           bool
+		ageDaysGt              int
+		withLastDeployDate     bool
+		dot                    bool
+		page                   cmdutil.Pagination
+	}
+
 	cmd := &cobra.Command{
-		Use:     "list <database>",
-		Short:   "List all branches of a database",
-		Args:    cmdutil.RequiredArgs("database"),
-		Aliases: []string{"ls"},
+		Use:               "list <database>",
+		Short:             "List all branches of a database",
+		Args:              cmdutil.RequiredArgs("database"),
+		ValidArgsFunction: cmdutil.DatabaseCompletionFunc(ch),
+		Aliases:           []string{"ls"},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if flags.productionOnly && flags.noProduction {
+				return errors.New("--production-only and --no-production can't be used together")
+			}
+			if flags.ageDaysGt < 0 {
+				return errors.New("--age-days-gt can't be negative")
+			}
+			if flags.withLastDeployDate && (flags.withOpenDeployRequests || flags.ageDaysGt > 0) {
+				return errors.New("--with-last-deploy-date can't be used with --with-open-deploy-requests or --age-days-gt")
+			}
+			if flags.dot {
+				if format := ch.Printer.Format(); !format.IsHuman() {
+					return fmt.Errorf("--output-dot can't be used with the output format %q", format.String())
+				}
+				// the graph needs every branch to connect them to their
+				// parents
+				for _, name := range []string{"exclude", "production-only", "no-production", "age-days-gt",
+					"has-open-deploy-request", "with-open-deploy-requests", "with-last-deploy-date", "page", "limit"} {
+					if cmd.Flags().Changed(name) {
+						return fmt.Errorf("--output-dot can't be used with --%s", name)
+					}
+				}
+			}
+			return flags.page.Validate(cmd, ch.Printer.IsHuman())
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
@@ -41,7 +83,12 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 				return err
 			}
 
-			end := ch.Printer.PrintProgress(fmt.Sprintf("Fetching branches for %s", printer.BoldBlue(database)))
+			// without a terminal, the progress is printed as a line, which
+			// would end up in the graph piped to dot
+			end := func() {}
+			if !flags.dot {
+				end = ch.Printer.PrintProgress(fmt.Sprintf("Fetching branches for %s", printer.BoldBlue(database)))
+			}
 			defer end()
 
 			branches, err := client.DatabaseBranches.List(ctx, &planetscale.ListDatabaseBranchesRequest{
@@ -57,17 +104,348 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 					return cmdutil.HandleError(err)
 				}
 			}
+
+			if flags.dot {
+				end()
+
+				graph, err := branchGraph(database, branches)
+				if err != nil {
+					return err
+				}
+
+				ch.Printer.Print(graph)
+				return nil
+			}
+
+			if len(flags.exclude) > 0 {
+				branches = excludeBranches(branches, flags.exclude)
+			}
+
+			if flags.productionOnly || flags.noProduction {
+				branches = filterProduction(branches, flags.productionOnly)
+			}
+
+			now := time.Now()
+			if flags.ageDaysGt > 0 {
+				branches = olderThan(branches, time.Duration(flags.ageDaysGt)*24*time.Hour, now)
+			}
+
+			// the deploy requests are fetched once, even if several flags
+			// need them
+			var drs []*planetscale.DeployRequest
+			listDeployRequests := func() ([]*planetscale.DeployRequest, error) {
+				if drs != nil {
+					return drs, nil
+				}
+
+				var err error
+				drs, err = client.DeployRequests.List(ctx, &planetscale.ListDeployRequestsRequest{
+					Organization: ch.Config.Organization,
+					Database:     database,
+				})
+				if err != nil {
+					return nil, cmdutil.HandleError(err)
+				}
+				return drs, nil
+			}
+
+			if flags.hasOpenDeployRequest {
+				drs, err := listDeployRequests()
+				if err != nil {
+					return err
+				}
+				branches = hasOpenDeployRequest(branches, drs)
+			}
+
+			if flags.withOpenDeployRequests {
+				drs, err := listDeployRequests()
+				if err != nil {
+					return err
+				}
+				end()
+
+				open := withOpenDeployRequests(branches, drs)
+				if len(open) == 0 && ch.Printer.IsHuman() {
+					ch.Printer.Printf("No branches with open deploy requests exist in %s.\n", printer.BoldBlue(database))
+					return nil
+				}
+
+				first, last := flags.page.Bounds(len(open))
+				if err := ch.Printer.PrintResource(open[first:last]); err != nil {
+					return err
+				}
+
+				ch.Printer.Print(flags.page.Footer(len(open)))
+				return nil
+			}
+
+			var deployed []*planetscale.DeployRequest
+			if flags.withLastDeployDate {
+				deployed, err = listDeployRequests()
+				if err != nil {
+					return err
+				}
+			}
 			end()
 
-			if len(branches) == 0 && ch.Printer.Format() == printer.Human {
+			if len(branches) == 0 && ch.Printer.IsHuman() {
 				ch.Printer.Printf("No branches exist in %s.\n", printer.BoldBlue(database))
 				return nil
 			}
 
-			return ch.Printer.PrintResource(toDatabaseBranches(branches))
+			first, last := flags.page.Bounds(len(branches))
+			switch {
+			case flags.ageDaysGt > 0:
+				err = ch.Printer.PrintResource(toAgedBranches(branches[first:last], now))
+			case flags.withLastDeployDate:
+				err = ch.Printer.PrintResource(toDeployedBranches(branches[first:last], deployed))
+			default:
+				err = ch.Printer.PrintResource(toDatabaseBranches(branches[first:last]))
+			}
+			if err != nil {
+				return err
+			}
+
+			ch.Printer.Print(flags.page.Footer(len(branches)))
+			return nil
 		},
 	}
 
 	cmd.Flags().BoolP("web", "w", false, "List branches in your web browser.")
+	cmd.Flags().BoolVar(&flags.withOpenDeployRequests, "with-open-deploy-requests", false,
+		"Only list branches that have open deploy requests, along with their numbers.")
+	cmd.Flags().BoolVar(&flags.hasOpenDeployRequest, "has-open-deploy-request", false,
+		"Only list branches that have at least one open deploy request. Can be combined with the other filters.")
+	cmd.Flags().StringSliceVar(&flags.exclude, "exclude", nil,
+		"Branch names to leave out of the list. Can be repeated.")
+	cmd.Flags().BoolVar(&flags.productionOnly, "production-only", false, "Only list production branches.")
+	cmd.Flags().BoolVar(&flags.noProduction, "no-production", false, "Only list development branches.")
+	cmd.Flags().IntVar(&flags.ageDaysGt, "age-days-gt", 0,
+		"Only list branches created more than this many days ago, along with their age.")
+	cmd.Flags().BoolVar(&flags.withLastDeployDate, "with-last-deploy-date", false,
+		"List when each branch was last deployed by a deploy request.")
+	cmd.Flags().BoolVar(&flags.dot, "output-dot", false,
+		"Print the branches as a Graphviz digraph with an edge from every parent branch to its child, to render with 'dot -Tsvg'.")
+	flags.page.AddFlags(cmd)
 	return cmd
 }
+
+// branchGraph returns the branches of the database as a Graphviz digraph in
+// the DOT language, with an edge from every parent branch to its child. It
+// returns an error if a parent branch isn't one of the branches.
+func branchGraph(database string, branches []*planetscale.DatabaseBranch) (string, error) {
+	names := make(map[string]bool, len(branches))
+	for _, b := range branches {
+		names[b.Name] = true
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "digraph %s {\n", strconv.Quote(database))
+	for _, b := range branches {
+		fmt.Fprintf(&buf, "  %s;\n", strconv.Quote(b.Name))
+	}
+
+	for _, b := range branches {
+		if b.ParentBranch == "" {
+			continue
+		}
+		if !names[b.ParentBranch] {
+			return "", fmt.Errorf("parent branch %s of branch %s does not exist in database %s",
+				printer.BoldBlue(b.ParentBranch), printer.BoldBlue(b.Name), printer.BoldBlue(database))
+		}
+		fmt.Fprintf(&buf, "  %s -> %s;\n", strconv.Quote(b.ParentBranch), strconv.Quote(b.Name))
+	}
+	buf.WriteString("}\n")
+
+	return buf.String(), nil
+}
+
+// olderThan returns the branches that were created more than age before now.
+func olderThan(branches []*planetscale.DatabaseBranch, age time.Duration, now time.Time) []*planetscale.DatabaseBranch {
+	out := make([]*planetscale.DatabaseBranch, 0, len(branches))
+	for _, b := range branches {
+		if now.Sub(b.CreatedAt) > age {
+			out = append(out, b)
+		}
+	}
+
+	return out
+}
+
+// agedBranch is a branch along with how long ago it was created.
+type agedBranch struct {
+	Name         string `header:"name" json:"name"`
+	ParentBranch string `header:"parent branch,n/a" json:"parent_branch"`
+	Production   bool   `header:"production" json:"production"`
+	Ready        bool   `header:"ready" json:"ready"`
+	Age          string `header:"age" json:"-" csv:"-"`
+	AgeDays      int    `json:"age_days" csv:"age_days"`
+	CreatedAt    int64  `header:"created_at,timestamp(ms|utc|human)" json:"created_at"`
+}
+
+func toAgedBranches(branches []*planetscale.DatabaseBranch, now time.Time) []*agedBranch {
+	out := make([]*agedBranch, 0, len(branches))
+	for _, b := range branches {
+		days := int(now.Sub(b.CreatedAt) / (24 * time.Hour))
+		out = append(out, &agedBranch{
+			Name:         b.Name,
+			ParentBranch: b.ParentBranch,
+			Production:   b.Production,
+			Ready:        b.Ready,
+			Age:          fmt.Sprintf("%d days", days),
+			AgeDays:      days,
+			CreatedAt:    printer.GetMilliseconds(b.CreatedAt),
+		})
+	}
+
+	return out
+}
+
+// deployedBranch is a branch along with when it was last deployed.
+type deployedBranch struct {
+	Name         string     `header:"name" json:"name"`
+	ParentBranch string     `header:"parent branch,n/a" json:"parent_branch"`
+	Production   bool       `header:"production" json:"production"`
+	Ready        bool       `header:"ready" json:"ready"`
+	LastDeploy   int64      `header:"last deploy,timestamp(ms|utc|human)" json:"-" csv:"last_deploy_at"`
+	LastDeployAt *time.Time `json:"last_deploy_at" csv:"-"`
+	CreatedAt    int64      `header:"created_at,timestamp(ms|utc|human)" json:"created_at"`
+}
+
+// lastDeploys returns when each branch was last deployed, keyed by the
+// branch. Only deploy requests whose deployment completed are counted.
+func lastDeploys(drs []*planetscale.DeployRequest) map[string]time.Time {
+	last := make(map[string]time.Time)
+	for _, dr := range drs {
+		if dr.Deployment == nil || dr.Deployment.State != "complete" {
+			continue
+		}
+
+		at := dr.Deployment.UpdatedAt
+		if dr.Deployment.FinishedAt != nil {
+			at = *dr.Deployment.FinishedAt
+		}
+
+		if at.After(last[dr.Branch]) {
+			last[dr.Branch] = at
+		}
+	}
+
+	return last
+}
+
+func toDeployedBranches(branches []*planetscale.DatabaseBranch, drs []*planetscale.DeployRequest) []*deployedBranch {
+	last := lastDeploys(drs)
+
+	out := make([]*deployedBranch, 0, len(branches))
+	for _, b := range branches {
+		d := &deployedBranch{
+			Name:         b.Name,
+			ParentBranch: b.ParentBranch,
+			Production:   b.Production,
+			Ready:        b.Ready,
+			CreatedAt:    printer.GetMilliseconds(b.CreatedAt),
+		}
+		if at, ok := last[b.Name]; ok {
+			at := at.UTC()
+			d.LastDeploy = printer.GetMilliseconds(at)
+			d.LastDeployAt = &at
+		}
+		out = append(out, d)
+	}
+
+	return out
+}
+
+// filterProduction returns the branches that are production branches if
+// production is true, or development branches otherwise.
+func filterProduction(branches []*planetscale.DatabaseBranch, production bool) []*planetscale.DatabaseBranch {
+	out := make([]*planetscale.DatabaseBranch, 0, len(branches))
+	for _, b := range branches {
+		if b.Production == production {
+			out = append(out, b)
+		}
+	}
+
+	return out
+}
+
+// excludeBranches returns the branches whose names are not in names.
+func excludeBranches(branches []*planetscale.DatabaseBranch, names []string) []*planetscale.DatabaseBranch {
+	excluded := make(map[string]bool, len(names))
+	for _, name := range names {
+		excluded[name] = true
+	}
+
+	out := make([]*planetscale.DatabaseBranch, 0, len(branches))
+	for _, b := range branches {
+		if !excluded[b.Name] {
+			out = append(out, b)
+		}
+	}
+
+	return out
+}
+
+// branchDeployRequests is a branch along with its open deploy requests.
+type branchDeployRequests struct {
+	Name           string   `header:"name" json:"name"`
+	DeployRequests string   `header:"deploy requests" json:"-"`
+	Numbers        []uint64 `json:"deploy_requests" csv:"-"`
+}
+
+// openDeployRequests returns the numbers of the open deploy requests, keyed
+// by their branch.
+func openDeployRequests(drs []*planetscale.DeployRequest) map[string][]uint64 {
+	numbers := make(map[string][]uint64)
+	for _, dr := range drs {
+		if dr.State != "open" {
+			continue
+		}
+		numbers[dr.Branch] = append(numbers[dr.Branch], dr.Number)
+	}
+
+	return numbers
+}
+
+// hasOpenDeployRequest returns the branches that are the source of at least
+// one open deploy request.
+func hasOpenDeployRequest(branches []*planetscale.DatabaseBranch, drs []*planetscale.DeployRequest) []*planetscale.DatabaseBranch {
+	numbers := openDeployRequests(drs)
+
+	out := make([]*planetscale.DatabaseBranch, 0, len(branches))
+	for _, b := range branches {
+		if _, ok := numbers[b.Name]; ok {
+			out = append(out, b)
+		}
+	}
+
+	return out
+}
+
+// withOpenDeployRequests returns the branches that are the source of at least
+// one open deploy request, in the order they were listed.
+func withOpenDeployRequests(branches []*planetscale.DatabaseBranch, drs []*planetscale.DeployRequest) []*branchDeployRequests {
+	numbers := openDeployRequests(drs)
+
+	out := make([]*branchDeployRequests, 0, len(numbers))
+	for _, b := range branches {
+		nums, ok := numbers[b.Name]
+		if !ok {
+			continue
+		}
+
+		refs := make([]string, 0, len(nums))
+		for _, n := range nums {
+			refs = append(refs, fmt.Sprintf("#%d", n))
+		}
+
+		out = append(out, &branchDeployRequests{
+			Name:           b.Name,
+			DeployRequests: strings.Join(refs, ", "),
+			Numbers:        nums,
+		})
+	}
+
+	return out
+}
@@ -3,7 +3,10 @@ package branch
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/config"
@@ -61,3 +64,542 @@ func TestBranch_ListCmd(t *testing.T) {
 	c.Assert(svc.ListFnInvoked, qt.IsTrue)
 	c.Assert(buf.String(), qt.JSONEquals, branches)
 }
+
+func TestBranch_ListCmd_WithOpenDeployRequests(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+
+	branches := []*ps.DatabaseBranch{
+		{Name: "main"},
+		{Name: "feature"},
+		{Name: "stale"},
+	}
+
+	drs := []*ps.DeployRequest{
+		{Number: 1, Branch: "feature", State: "open"},
+		{Number: 2, Branch: "stale", State: "closed"},
+		{Number: 3, Branch: "feature", State: "open"},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabaseBranchesRequest) ([]*ps.DatabaseBranch, error) {
+			c.Assert(req.Database, qt.Equals, db)
+			c.Assert(req.Organization, qt.Equals, org)
+
+			return branches, nil
+		},
+	}
+
+	drSvc := &mock.DeployRequestsService{
+		ListFn: func(ctx context.Context, req *ps.ListDeployRequestsRequest) ([]*ps.DeployRequest, error) {
+			c.Assert(req.Database, qt.Equals, db)
+			c.Assert(req.Organization, qt.Equals, org)
+
+			return drs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+				DeployRequests:   drSvc,
+			}, nil
+
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{db, "--with-open-deploy-requests"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListFnInvoked, qt.IsTrue)
+	c.Assert(drSvc.ListFnInvoked, qt.IsTrue)
+
+	res := []map[string]interface{}{
+		{"name": "feature", "deploy_requests": []uint64{1, 3}},
+	}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestBranch_ListCmd_HasOpenDeployRequest(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	branches := []*ps.DatabaseBranch{
+		{Name: "main"},
+		{Name: "feature"},
+		{Name: "other"},
+		{Name: "stale"},
+	}
+
+	drs := []*ps.DeployRequest{
+		{Number: 1, Branch: "feature", State: "open"},
+		{Number: 2, Branch: "stale", State: "closed"},
+		{Number: 3, Branch: "other", State: "open"},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabaseBranchesRequest) ([]*ps.DatabaseBranch, error) {
+			return branches, nil
+		},
+	}
+
+	calls := 0
+	drSvc := &mock.DeployRequestsService{
+		ListFn: func(ctx context.Context, req *ps.ListDeployRequestsRequest) ([]*ps.DeployRequest, error) {
+			calls++
+			return drs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+				DeployRequests:   drSvc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "--has-open-deploy-request", "--with-open-deploy-requests", "--exclude", "other"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(calls, qt.Equals, 1)
+
+	res := []map[string]interface{}{
+		{"name": "feature", "deploy_requests": []uint64{1}},
+	}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestBranch_ListCmd_Exclude(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+
+	branches := []*ps.DatabaseBranch{
+		{Name: "main"},
+		{Name: "staging"},
+		{Name: "feature"},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabaseBranchesRequest) ([]*ps.DatabaseBranch, error) {
+			c.Assert(req.Database, qt.Equals, db)
+			c.Assert(req.Organization, qt.Equals, org)
+
+			return branches, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{db, "--exclude", "main", "--exclude", "staging"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListFnInvoked, qt.IsTrue)
+	c.Assert(buf.String(), qt.JSONEquals, branches[2:])
+}
+
+func TestBranch_ListCmd_Production(t *testing.T) {
+	c := qt.New(t)
+
+	org := "planetscale"
+	db := "planetscale"
+
+	branches := []*ps.DatabaseBranch{
+		{Name: "main", Production: true},
+		{Name: "staging"},
+		{Name: "feature"},
+	}
+
+	tests := []struct {
+		flag string
+		want []*ps.DatabaseBranch
+	}{
+		{flag: "--production-only", want: branches[:1]},
+		{flag: "--no-production", want: branches[1:]},
+	}
+
+	for _, tt := range tests {
+		c.Run(tt.flag, func(c *qt.C) {
+			var buf bytes.Buffer
+			format := printer.JSON
+			p := printer.NewPrinter(&format)
+			p.SetResourceOutput(&buf)
+
+			svc := &mock.DatabaseBranchesService{
+				ListFn: func(ctx context.Context, req *ps.ListDatabaseBranchesRequest) ([]*ps.DatabaseBranch, error) {
+					return branches, nil
+				},
+			}
+
+			ch := &cmdutil.Helper{
+				Printer: p,
+				Config: &config.Config{
+					Organization: org,
+				},
+				Client: func() (*ps.Client, error) {
+					return &ps.Client{
+						DatabaseBranches: svc,
+					}, nil
+				},
+			}
+
+			cmd := ListCmd(ch)
+			cmd.SetArgs([]string{db, tt.flag})
+			err := cmd.Execute()
+
+			c.Assert(err, qt.IsNil)
+			c.Assert(buf.String(), qt.JSONEquals, tt.want)
+		})
+	}
+}
+
+func TestBranch_ListCmd_ProductionFlagsExclusive(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+
+	svc := &mock.DatabaseBranchesService{}
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "--production-only", "--no-production"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "--production-only and --no-production can't be used together")
+	c.Assert(svc.ListFnInvoked, qt.IsFalse)
+}
+
+func TestBranch_ListCmd_AgeDaysGt(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	now := time.Now()
+	branches := []*ps.DatabaseBranch{
+		{Name: "main", Production: true, CreatedAt: now.Add(-100 * 24 * time.Hour)},
+		{Name: "stale", CreatedAt: now.Add(-31 * 24 * time.Hour)},
+		{Name: "fresh", CreatedAt: now.Add(-2 * 24 * time.Hour)},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabaseBranchesRequest) ([]*ps.DatabaseBranch, error) {
+			return branches, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "--age-days-gt", "30"})
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	var out []struct {
+		Name    string `json:"name"`
+		AgeDays int    `json:"age_days"`
+	}
+	err = json.Unmarshal(buf.Bytes(), &out)
+	c.Assert(err, qt.IsNil)
+	c.Assert(out, qt.HasLen, 2)
+	c.Assert(out[0].Name, qt.Equals, "main")
+	c.Assert(out[0].AgeDays, qt.Equals, 100)
+	c.Assert(out[1].Name, qt.Equals, "stale")
+	c.Assert(out[1].AgeDays, qt.Equals, 31)
+}
+
+func TestBranch_ListCmd_WithLastDeployDate(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	branches := []*ps.DatabaseBranch{
+		{Name: "main", Production: true},
+		{Name: "feature"},
+	}
+
+	older := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	newer := time.Date(2021, 3, 4, 12, 30, 0, 0, time.UTC)
+	drs := []*ps.DeployRequest{
+		{Number: 1, Branch: "feature", State: "closed", Deployment: &ps.Deployment{State: "complete", FinishedAt: &older}},
+		{Number: 2, Branch: "feature", State: "closed", Deployment: &ps.Deployment{State: "complete", FinishedAt: &newer}},
+		{Number: 3, Branch: "feature", State: "open", Deployment: &ps.Deployment{State: "pending"}},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabaseBranchesRequest) ([]*ps.DatabaseBranch, error) {
+			return branches, nil
+		},
+	}
+
+	drSvc := &mock.DeployRequestsService{
+		ListFn: func(ctx context.Context, req *ps.ListDeployRequestsRequest) ([]*ps.DeployRequest, error) {
+			return drs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+				DeployRequests:   drSvc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "--with-last-deploy-date"})
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	var out []struct {
+		Name         string     `json:"name"`
+		LastDeployAt *time.Time `json:"last_deploy_at"`
+	}
+	err = json.Unmarshal(buf.Bytes(), &out)
+	c.Assert(err, qt.IsNil)
+	c.Assert(out, qt.HasLen, 2)
+	c.Assert(out[0].Name, qt.Equals, "main")
+	c.Assert(out[0].LastDeployAt, qt.IsNil)
+	c.Assert(out[1].Name, qt.Equals, "feature")
+	c.Assert(*out[1].LastDeployAt, qt.Equals, newer)
+	c.Assert(buf.String(), qt.Contains, `"last_deploy_at": "2021-03-04T12:30:00Z"`)
+}
+
+func TestBranch_ListCmd_JSONLines(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSONLines
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	branches := []*ps.DatabaseBranch{
+		{Name: "main", Production: true},
+		{Name: "feature"},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabaseBranchesRequest) ([]*ps.DatabaseBranch, error) {
+			return branches, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"planetscale"})
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	c.Assert(lines, qt.HasLen, 2)
+	c.Assert(lines[0], qt.JSONEquals, branches[0])
+	c.Assert(lines[1], qt.JSONEquals, branches[1])
+}
+
+func TestBranch_ListCmd_Page(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	branches := []*ps.DatabaseBranch{
+		{Name: "main"},
+		{Name: "feature-1"},
+		{Name: "feature-2"},
+		{Name: "feature-3"},
+		{Name: "feature-4"},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabaseBranchesRequest) ([]*ps.DatabaseBranch, error) {
+			return branches, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "--page", "2", "--limit", "2"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.JSONEquals, branches[2:4])
+}
+
+func TestBranch_ListCmd_OutputDot(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&buf)
+
+	svc := &mock.DatabaseBranchesService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabaseBranchesRequest) ([]*ps.DatabaseBranch, error) {
+			return []*ps.DatabaseBranch{
+				{Name: "main", Production: true},
+				{Name: "dev", ParentBranch: "main"},
+				{Name: "feature", ParentBranch: "dev"},
+			}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"mydb", "--output-dot"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.Equals, `digraph "mydb" {
+  "main";
+  "dev";
+  "feature";
+  "main" -> "dev";
+  "dev" -> "feature";
+}
+`)
+}
+
+func TestBranch_ListCmd_OutputDotMissingParent(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&bytes.Buffer{})
+
+	svc := &mock.DatabaseBranchesService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabaseBranchesRequest) ([]*ps.DatabaseBranch, error) {
+			return []*ps.DatabaseBranch{
+				{Name: "dev", ParentBranch: "main"},
+			}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"mydb", "--output-dot"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "parent branch .*main.* of branch .*dev.* does not exist in database .*mydb.*")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -11,62 +12,86 @@ import (
 	"github.com/planetscale/cli/internal/printer"
 	"github.com/spf13/cobra"
 
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
 	ps "github.com/planetscale/planetscale-go/planetscale"
 )
 
 func PromoteCmd(ch *cmdutil.Helper) *cobra.Command {
 	promoteReq := &ps.PromoteRequest{}
+	var force bool
 
 	cmd := &cobra.Command{
-		Use:     "promote <database> <branch> [options]",
-		Short:   "Promote a new branch from a database",
-		Args:    cmdutil.RequiredArgs("source-database", "branch"),
-		Aliases: []string{"b"},
-		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-			if len(args) != 0 {
-				return nil, cobra.ShellCompDirectiveNoFileComp
-			}
+		Use:   "promote <database> <branch> [options]",
+		Short: "Promote a new branch from a database",
+		Long: `Promote a branch of a database to production. Promoting a branch can't be
+undone, so you're asked to confirm it unless --force is given.`,
+		Args:              cmdutil.RequiredArgs("source-database", "branch"),
+		Aliases:           []string{"b"},
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source := args[0]
+			branch := args[1]
+
+			promoteReq.Database = source
+			promoteReq.Organization = ch.Config.Organization
+			promoteReq.Branch = branch
 
 			client, err := ch.Client()
 			if err != nil {
-				return nil, cobra.ShellCompDirectiveNoFileComp
-			}
-
-			org := ch.Config.Organization // --org flag
-			if org == "" {
-				cfg, err := ch.ConfigFS.DefaultConfig()
-				if err != nil {
-					return nil, cobra.ShellCompDirectiveNoFileComp
-				}
-
-				org = cfg.Organization
+				return err
 			}
 
-			databases, err := client.Databases.List(cmd.Context(), &ps.ListDatabasesRequest{
-				Organization: org,
+			current, err := client.DatabaseBranches.Get(cmd.Context(), &ps.GetDatabaseBranchRequest{
+				Organization: ch.Config.Organization,
+				Database:     source,
+				Branch:       branch,
 			})
 			if err != nil {
-				return nil, cobra.ShellCompDirectiveNoFileComp
+				switch cmdutil.ErrCode(err) {
+				case ps.ErrNotFound:
+					return fmt.Errorf("branch %s does not exist in database %s",
+						printer.BoldBlue(branch), printer.BoldBlue(source))
+				default:
+					return cmdutil.HandleError(err)
+				}
 			}
 
-			candidates := make([]string, 0, len(databases))
-			for _, db := range databases {
-				candidates = append(candidates, db.Name)
+			if current.Production {
+				return fmt.Errorf("branch %s in %s is already a production branch",
+					printer.BoldBlue(branch), printer.BoldBlue(source))
 			}
 
-			return candidates, cobra.ShellCompDirectiveNoFileComp
-		},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			source := args[0]
-			branch := args[1]
+			if !force {
+				if format := ch.Printer.Format(); !format.IsHuman() {
+					return fmt.Errorf("cannot promote branch with the output format %q (run with -force to override)", format.String())
+				}
 
-			promoteReq.Database = source
-			promoteReq.Organization = ch.Config.Organization
-			promoteReq.Branch = branch
+				confirmationName := fmt.Sprintf("%s/%s", source, branch)
+				if !printer.IsTTY {
+					return fmt.Errorf("cannot confirm promotion of branch %q (run with -force to override)", confirmationName)
+				}
 
-			client, err := ch.Client()
-			if err != nil {
-				return err
+				confirmationMessage := fmt.Sprintf("%s %s %s", printer.Bold("Please type"), printer.BoldBlue(confirmationName), printer.Bold("to confirm:"))
+
+				prompt := &survey.Input{
+					Message: confirmationMessage,
+				}
+
+				var userInput string
+				err := survey.AskOne(prompt, &userInput)
+				if err != nil {
+					if err == terminal.InterruptErr {
+						os.Exit(0)
+					} else {
+						return err
+					}
+				}
+
+				// If the confirmations don't match up, let's return an error.
+				if userInput != confirmationName {
+					return errors.New("incorrect branch name entered, skipping branch promotion")
+				}
 			}
 
 			end := ch.Printer.PrintProgress(fmt.Sprintf("Promoting %s branch in %s to production...", printer.BoldBlue(branch), printer.BoldBlue(source)))
@@ -102,7 +127,7 @@ func PromoteCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			end()
 
-			if ch.Printer.Format() == printer.Human {
+			if ch.Printer.IsHuman() {
 				if promotionRequest.State == "lint_error" {
 
 					var sb strings.Builder
@@ -132,6 +157,7 @@ func PromoteCmd(ch *cmdutil.Helper) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVar(&force, "force", false, "Promote a branch without confirmation")
 	return cmd
 }
 
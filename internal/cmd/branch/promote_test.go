@@ -69,7 +69,7 @@ func TestBranch_PromoteCmd(t *testing.T) {
 	}
 
 	cmd := PromoteCmd(ch)
-	cmd.SetArgs([]string{db, branch})
+	cmd.SetArgs([]string{db, branch, "--force"})
 	err := cmd.Execute()
 
 	c.Assert(err, qt.IsNil)
@@ -78,3 +78,86 @@ func TestBranch_PromoteCmd(t *testing.T) {
 	c.Assert(svc.GetPromotionRequestFnInvoked, qt.IsTrue)
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestBranch_PromoteCmd_RequiresForce(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	branch := "development"
+
+	svc := &mock.DatabaseBranchesService{
+		GetFn: func(ctx context.Context, req *ps.GetDatabaseBranchRequest) (*ps.DatabaseBranch, error) {
+			return &ps.DatabaseBranch{Name: branch}, nil
+		},
+		PromoteFn: func(ctx context.Context, req *ps.PromoteRequest) (*ps.BranchPromotionRequest, error) {
+			return &ps.BranchPromotionRequest{Branch: branch, State: "promoted"}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := PromoteCmd(ch)
+	cmd.SetArgs([]string{db, branch})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, `cannot promote branch with the output format "json" \(run with -force to override\)`)
+	c.Assert(svc.PromoteFnInvoked, qt.IsFalse)
+}
+
+func TestBranch_PromoteCmd_AlreadyProduction(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	branch := "main"
+
+	svc := &mock.DatabaseBranchesService{
+		GetFn: func(ctx context.Context, req *ps.GetDatabaseBranchRequest) (*ps.DatabaseBranch, error) {
+			return &ps.DatabaseBranch{Name: branch, Production: true}, nil
+		},
+		PromoteFn: func(ctx context.Context, req *ps.PromoteRequest) (*ps.BranchPromotionRequest, error) {
+			return &ps.BranchPromotionRequest{Branch: branch, State: "promoted"}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := PromoteCmd(ch)
+	cmd.SetArgs([]string{db, branch, "--force"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "branch .*main.* in .*planetscale.* is already a production branch")
+	c.Assert(svc.GetFnInvoked, qt.IsTrue)
+	c.Assert(svc.PromoteFnInvoked, qt.IsFalse)
+}
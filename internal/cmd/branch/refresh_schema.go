@@ -1,7 +1,9 @@
 package branch
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
@@ -10,10 +12,23 @@ import (
 )
 
 func RefreshSchemaCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		wait        bool
+		waitTimeout time.Duration
+	}
+
 	cmd := &cobra.Command{
 		Use:   "refresh-schema <database> <branch>",
 		Short: "Refresh the schema for a database branch",
-		Args:  cmdutil.RequiredArgs("database", "branch"),
+		Long: `Refresh the schema snapshot of a database branch.
+
+The refresh happens in the background, so the command returns right away. With
+--wait, it checks the schema until it changes and prints the tables that
+changed compared to the previous snapshot. The API doesn't report when a
+refresh is done, so if the schema doesn't change within --wait-timeout, the
+branch is either already up to date or the refresh is still running.`,
+		Args:              cmdutil.RequiredArgs("database", "branch"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database, branch := args[0], args[1]
@@ -26,6 +41,14 @@ func RefreshSchemaCmd(ch *cmdutil.Helper) *cobra.Command {
 			end := ch.Printer.PrintProgress(fmt.Sprintf("Refreshing schema for %s in %s", printer.BoldBlue(branch), printer.BoldBlue(database)))
 			defer end()
 
+			var before map[string]string
+			if flags.wait {
+				before, err = branchSchema(ctx, ch, client, database, branch)
+				if err != nil {
+					return err
+				}
+			}
+
 			err = client.DatabaseBranches.RefreshSchema(ctx, &planetscale.RefreshSchemaRequest{
 				Organization: ch.Config.Organization,
 				Database:     database,
@@ -40,20 +63,90 @@ func RefreshSchemaCmd(ch *cmdutil.Helper) *cobra.Command {
 					return cmdutil.HandleError(err)
 				}
 			}
+
+			if !flags.wait {
+				end()
+
+				if ch.Printer.IsHuman() {
+					ch.Printer.Printf("Successfully refreshed schema for %s in %s.\n", printer.BoldBlue(branch), printer.BoldBlue(database))
+					return nil
+				}
+
+				return ch.Printer.PrintResource(
+					map[string]string{
+						"result": "schema refreshed",
+					},
+				)
+			}
+
+			diffs, err := waitSchemaChange(ctx, ch, client, database, branch, before, flags.waitTimeout)
+			if err != nil {
+				return err
+			}
 			end()
 
-			if ch.Printer.Format() == printer.Human {
-				ch.Printer.Printf("Successfully refreshed schema for %s in %s.\n", printer.BoldBlue(branch), printer.BoldBlue(database))
-				return nil
+			if ch.Printer.IsHuman() {
+				if len(diffs) == 0 {
+					ch.Printer.Printf("Schema for %s in %s didn't change within %s. It's either already up to date or the refresh is still running.\n",
+						printer.BoldBlue(branch), printer.BoldBlue(database), flags.waitTimeout)
+					return nil
+				}
+
+				ch.Printer.Printf("Successfully refreshed schema for %s in %s.\n\n", printer.BoldBlue(branch), printer.BoldBlue(database))
+				return printDiffs(ch, diffs)
+			}
+
+			result := "schema refreshed"
+			if len(diffs) == 0 {
+				result = "no changes within wait timeout"
+				diffs = []*planetscale.Diff{}
 			}
 
 			return ch.Printer.PrintResource(
-				map[string]string{
-					"result": "schema refreshed",
+				map[string]interface{}{
+					"result":  result,
+					"changes": diffs,
 				},
 			)
 		},
 	}
 
+	cmd.Flags().BoolVar(&flags.wait, "wait", false, "Wait until the refreshed schema is available")
+	cmd.Flags().DurationVar(&flags.waitTimeout, "wait-timeout", 30*time.Second, "How long to wait for the schema to change with --wait")
+
 	return cmd
 }
+
+// refreshPollInterval is how often the schema is checked while waiting for a
+// refresh to finish.
+var refreshPollInterval = 2 * time.Second
+
+// waitSchemaChange polls the schema of the branch until it differs from
+// before, and returns the changes. It returns no changes if the schema is the
+// same once the timeout expires, which doesn't tell whether the branch was
+// already up to date or the refresh is still running.
+func waitSchemaChange(ctx context.Context, ch *cmdutil.Helper, client *planetscale.Client, database, branch string, before map[string]string, timeout time.Duration) ([]*planetscale.Diff, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(refreshPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, nil
+		case <-ticker.C:
+			after, err := branchSchema(ctx, ch, client, database, branch)
+			if err != nil {
+				return nil, err
+			}
+
+			if diffs := diffSchemas(before, after); len(diffs) > 0 {
+				return diffs, nil
+			}
+		}
+	}
+}
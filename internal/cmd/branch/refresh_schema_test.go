@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/planetscale/cli/internal/cmdutil"
@@ -53,4 +54,113 @@ func TestSnapshot_CreateCmd(t *testing.T) {
 
 	c.Assert(err, qt.IsNil)
 	c.Assert(svc.RefreshSchemaFnInvoked, qt.IsTrue)
+	// the refresh happens in the background, so the schema isn't compared
+	// without --wait
+	c.Assert(svc.SchemaFnInvoked, qt.IsFalse)
+
+	res := map[string]interface{}{
+		"result": "schema refreshed",
+	}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestSnapshot_CreateCmd_Wait(t *testing.T) {
+	c := qt.New(t)
+
+	old := refreshPollInterval
+	refreshPollInterval = time.Millisecond
+	c.Cleanup(func() { refreshPollInterval = old })
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	calls := 0
+	svc := &mock.DatabaseBranchesService{
+		RefreshSchemaFn: func(ctx context.Context, req *ps.RefreshSchemaRequest) error {
+			return nil
+		},
+		SchemaFn: func(ctx context.Context, req *ps.BranchSchemaRequest) ([]*ps.Diff, error) {
+			calls++
+			// the refreshed schema only shows up on the third poll
+			if calls < 4 {
+				return []*ps.Diff{{Name: "users", Raw: "CREATE TABLE `users` (\n  `id` int\n)"}}, nil
+			}
+			return []*ps.Diff{
+				{Name: "posts", Raw: "CREATE TABLE `posts` (\n  `id` int\n)"},
+				{Name: "users", Raw: "CREATE TABLE `users` (\n  `id` int\n)"},
+			}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := RefreshSchemaCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "development", "--wait", "--wait-timeout", "10s"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(calls, qt.Equals, 4)
+
+	res := map[string]interface{}{
+		"result": "schema refreshed",
+		"changes": []*ps.Diff{
+			{Name: "posts", Raw: "+CREATE TABLE `posts` (\n+  `id` int\n+)"},
+		},
+	}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestSnapshot_CreateCmd_NoChanges(t *testing.T) {
+	c := qt.New(t)
+
+	old := refreshPollInterval
+	refreshPollInterval = time.Millisecond
+	c.Cleanup(func() { refreshPollInterval = old })
+
+	var buf bytes.Buffer
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&buf)
+
+	svc := &mock.DatabaseBranchesService{
+		RefreshSchemaFn: func(ctx context.Context, req *ps.RefreshSchemaRequest) error {
+			return nil
+		},
+		SchemaFn: func(ctx context.Context, req *ps.BranchSchemaRequest) ([]*ps.Diff, error) {
+			return []*ps.Diff{{Name: "users", Raw: "CREATE TABLE `users` (\n  `id` int\n)"}}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := RefreshSchemaCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "development", "--wait", "--wait-timeout", "20ms"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.RefreshSchemaFnInvoked, qt.IsTrue)
+	c.Assert(buf.String(), qt.Contains,
+		"Schema for development in planetscale didn't change within 20ms. It's either already up to date or the refresh is still running.\n")
 }
@@ -0,0 +1,140 @@
+package branch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+	ps "github.com/planetscale/planetscale-go/planetscale"
+	"github.com/spf13/cobra"
+)
+
+// RestoreCmd is the command for restoring a backup of a branch into a new
+// branch.
+func RestoreCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		intoBranch  string
+		wait        bool
+		waitTimeout time.Duration
+	}
+
+	cmd := &cobra.Command{
+		Use:   "restore <database> <branch> <backup> --into-branch <new-branch>",
+		Short: "Restore a backup of a branch into a new branch",
+		Long: `Restore a backup of a branch into a new branch.
+
+PlanetScale restores backups by creating a new branch from them, so the branch
+the backup was taken from is left untouched. Use --into-branch to name the
+branch to create.`,
+		Args:              cmdutil.RequiredArgs("database", "branch", "backup"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			database, branch, backup := args[0], args[1], args[2]
+
+			if flags.intoBranch == "" {
+				return errors.New("--into-branch is required, backups can only be restored into a new branch")
+			}
+
+			client, err := ch.Client()
+			if err != nil {
+				return err
+			}
+
+			end := ch.Printer.PrintProgress(fmt.Sprintf("Restoring backup %s into branch %s...", printer.BoldBlue(backup), printer.BoldBlue(flags.intoBranch)))
+			defer end()
+
+			_, err = client.Backups.Get(ctx, &ps.GetBackupRequest{
+				Organization: ch.Config.Organization,
+				Database:     database,
+				Branch:       branch,
+				Backup:       backup,
+			})
+			if err != nil {
+				switch cmdutil.ErrCode(err) {
+				case ps.ErrNotFound:
+					return fmt.Errorf("backup %s does not exist in branch %s of %s (organization: %s)",
+						printer.BoldBlue(backup), printer.BoldBlue(branch), printer.BoldBlue(database), printer.BoldBlue(ch.Config.Organization))
+				default:
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			dbBranch, err := client.DatabaseBranches.Create(ctx, &ps.CreateDatabaseBranchRequest{
+				Organization: ch.Config.Organization,
+				Database:     database,
+				Name:         flags.intoBranch,
+				ParentBranch: branch,
+				BackupID:     backup,
+			})
+			if err != nil {
+				return cmdutil.HandleError(err)
+			}
+
+			if flags.wait {
+				dbBranch, err = waitBranchReady(ctx, client, &ps.GetDatabaseBranchRequest{
+					Organization: ch.Config.Organization,
+					Database:     database,
+					Branch:       dbBranch.Name,
+				}, flags.waitTimeout)
+				if err != nil {
+					return err
+				}
+			}
+
+			end()
+
+			if ch.Printer.IsHuman() {
+				if flags.wait {
+					ch.Printer.Printf("Backup %s was successfully restored into branch %s.\n", printer.BoldBlue(backup), printer.BoldBlue(dbBranch.Name))
+				} else {
+					ch.Printer.Printf("Backup %s is being restored into branch %s.\n", printer.BoldBlue(backup), printer.BoldBlue(dbBranch.Name))
+				}
+				return nil
+			}
+
+			return ch.Printer.PrintResource(ToDatabaseBranch(dbBranch))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.intoBranch, "into-branch", "", "Name of the new branch to restore the backup into")
+	cmd.Flags().BoolVar(&flags.wait, "wait", false, "Wait until the restored branch is ready")
+	cmd.Flags().DurationVar(&flags.waitTimeout, "wait-timeout", 30*time.Minute, "How long to wait for the restored branch to be ready")
+
+	return cmd
+}
+
+// restorePollInterval is how often a branch is checked while waiting for a
+// restore to finish.
+var restorePollInterval = 5 * time.Second
+
+// waitBranchReady polls the branch until it's ready.
+func waitBranchReady(ctx context.Context, client *ps.Client, getReq *ps.GetDatabaseBranchRequest, timeout time.Duration) (*ps.DatabaseBranch, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(restorePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for branch %s to be ready", printer.BoldBlue(getReq.Branch))
+		case <-ticker.C:
+			b, err := client.DatabaseBranches.Get(ctx, getReq)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, fmt.Errorf("timed out waiting for branch %s to be ready", printer.BoldBlue(getReq.Branch))
+				}
+				return nil, cmdutil.HandleError(err)
+			}
+
+			if b.Ready {
+				return b, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package branch
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/config"
+	"github.com/planetscale/cli/internal/mock"
+	"github.com/planetscale/cli/internal/printer"
+
+	qt "github.com/frankban/quicktest"
+	ps "github.com/planetscale/planetscale-go/planetscale"
+)
+
+func TestBranch_RestoreCmd(t *testing.T) {
+	c := qt.New(t)
+
+	old := restorePollInterval
+	restorePollInterval = time.Millisecond
+	c.Cleanup(func() { restorePollInterval = old })
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	branch := "main"
+	backup := "mybackup"
+	into := "restored"
+
+	res := &ps.DatabaseBranch{Name: into, ParentBranch: branch, Ready: true}
+
+	backupSvc := &mock.BackupsService{
+		GetFn: func(ctx context.Context, req *ps.GetBackupRequest) (*ps.Backup, error) {
+			c.Assert(req.Organization, qt.Equals, org)
+			c.Assert(req.Database, qt.Equals, db)
+			c.Assert(req.Branch, qt.Equals, branch)
+			c.Assert(req.Backup, qt.Equals, backup)
+
+			return &ps.Backup{PublicID: backup}, nil
+		},
+	}
+
+	polls := 0
+	branchSvc := &mock.DatabaseBranchesService{
+		CreateFn: func(ctx context.Context, req *ps.CreateDatabaseBranchRequest) (*ps.DatabaseBranch, error) {
+			c.Assert(req.Organization, qt.Equals, org)
+			c.Assert(req.Database, qt.Equals, db)
+			c.Assert(req.Name, qt.Equals, into)
+			c.Assert(req.ParentBranch, qt.Equals, branch)
+			c.Assert(req.BackupID, qt.Equals, backup)
+
+			return &ps.DatabaseBranch{Name: into, ParentBranch: branch}, nil
+		},
+		GetFn: func(ctx context.Context, req *ps.GetDatabaseBranchRequest) (*ps.DatabaseBranch, error) {
+			c.Assert(req.Branch, qt.Equals, into)
+
+			polls++
+			if polls < 3 {
+				return &ps.DatabaseBranch{Name: into, ParentBranch: branch}, nil
+			}
+			return res, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Backups:          backupSvc,
+				DatabaseBranches: branchSvc,
+			}, nil
+		},
+	}
+
+	cmd := RestoreCmd(ch)
+	cmd.SetArgs([]string{db, branch, backup, "--into-branch", into, "--wait"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(backupSvc.GetFnInvoked, qt.IsTrue)
+	c.Assert(branchSvc.CreateFnInvoked, qt.IsTrue)
+	c.Assert(polls, qt.Equals, 3)
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestBranch_RestoreCmd_BackupNotFound(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+
+	backupSvc := &mock.BackupsService{
+		GetFn: func(ctx context.Context, req *ps.GetBackupRequest) (*ps.Backup, error) {
+			return nil, &ps.Error{Code: ps.ErrNotFound}
+		},
+	}
+	branchSvc := &mock.DatabaseBranchesService{}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Backups:          backupSvc,
+				DatabaseBranches: branchSvc,
+			}, nil
+		},
+	}
+
+	cmd := RestoreCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "main", "mybackup", "--into-branch", "restored"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "backup .*mybackup.* does not exist.*")
+	c.Assert(branchSvc.CreateFnInvoked, qt.IsFalse)
+}
+
+func TestBranch_RestoreCmd_RequiresIntoBranch(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.JSON
+	ch := &cmdutil.Helper{
+		Printer: printer.NewPrinter(&format),
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+	}
+
+	cmd := RestoreCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "main", "mybackup"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "--into-branch is required.*")
+}
@@ -2,7 +2,11 @@ package branch
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"html"
+	"io/ioutil"
+	"os"
 	"strings"
 
 	"github.com/fatih/color"
@@ -16,17 +20,38 @@ import (
 // SchemaCmd is the command for showing the schema of a branch.
 func SchemaCmd(ch *cmdutil.Helper) *cobra.Command {
 	var flags struct {
-		web bool
+		web         bool
+		tables      []string
+		ignore      []string
+		createTable bool
+		html        bool
+		markdown    bool
+		title       string
+		outputFile  string
 	}
 
 	cmd := &cobra.Command{
-		Use:   "schema <database> <branch>",
-		Short: "Show the schema of a branch",
-		Args:  cmdutil.RequiredArgs("database", "branch"),
+		Use:               "schema <database> <branch>",
+		Short:             "Show the schema of a branch",
+		Args:              cmdutil.RequiredArgs("database", "branch"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database, branch := args[0], args[1]
 
+			outputs := 0
+			for _, set := range []bool{flags.html, flags.markdown, flags.createTable} {
+				if set {
+					outputs++
+				}
+			}
+			if outputs > 1 {
+				return errors.New("only one of --output-html, --output-markdown and --output-create-table can be used")
+			}
+			if flags.outputFile != "" && outputs == 0 {
+				return errors.New("--output-file can only be used with --output-html, --output-markdown or --output-create-table")
+			}
+
 			if flags.web {
 				ch.Printer.Println("🌐  Redirecting you to your branch schema in your web browser.")
 				return browser.OpenURL(fmt.Sprintf("%s/%s/%s/%s/schema", cmdutil.ApplicationURL, ch.Config.Organization, database, branch))
@@ -51,7 +76,58 @@ func SchemaCmd(ch *cmdutil.Helper) *cobra.Command {
 				}
 			}
 
-			if ch.Printer.Format() != printer.Human {
+			if len(flags.tables) > 0 {
+				schemas, err = filterTables(schemas, flags.tables)
+				if err != nil {
+					return err
+				}
+			}
+
+			if len(flags.ignore) > 0 {
+				var missing []string
+				schemas, missing = ignoreTables(schemas, flags.ignore)
+				if len(missing) > 0 {
+					// stderr, so the warning doesn't end up in the schema
+					// with --output-create-table or a non-human format
+					fmt.Fprintf(os.Stderr, "%s ignored table %s does not exist in the schema\n",
+						printer.BoldRed("WARNING:"), printer.BoldBlue(strings.Join(missing, ", ")))
+				}
+			}
+
+			if outputs > 0 {
+				var flag, doc string
+				switch {
+				case flags.html:
+					title := flags.title
+					if title == "" {
+						title = fmt.Sprintf("Schema of %s/%s", database, branch)
+					}
+					flag, doc = "--output-html", schemaHTML(title, schemas)
+				case flags.markdown:
+					flag, doc = "--output-markdown", schemaMarkdown(flags.title, schemas)
+				case flags.createTable:
+					flag, doc = "--output-create-table", createTableStatements(schemas)
+				}
+
+				if format := ch.Printer.Format(); !format.IsHuman() {
+					return fmt.Errorf("%s can't be used with the output format %q", flag, format.String())
+				}
+
+				if flags.outputFile == "" {
+					ch.Printer.Print(doc)
+					return nil
+				}
+
+				if err := ioutil.WriteFile(flags.outputFile, []byte(doc), 0644); err != nil {
+					return fmt.Errorf("writing schema to %s: %s", flags.outputFile, err)
+				}
+
+				ch.Printer.Printf("Schema of %s/%s written to %s.\n",
+					printer.BoldBlue(database), printer.BoldBlue(branch), printer.BoldBlue(flags.outputFile))
+				return nil
+			}
+
+			if !ch.Printer.IsHuman() {
 				return ch.Printer.PrintResource(schemas)
 			}
 
@@ -79,6 +155,128 @@ func SchemaCmd(ch *cmdutil.Helper) *cobra.Command {
 	}
 
 	cmd.PersistentFlags().BoolVar(&flags.web, "web", false, "Open in your web browser")
+	cmd.Flags().StringSliceVar(&flags.tables, "table", nil, "Only show the schema of these tables. Can be repeated.")
+	cmd.Flags().StringSliceVar(&flags.ignore, "ignore-tables", nil,
+		"Leave these tables out of the schema, such as migration history tables. Can be repeated or comma-separated.")
+	cmd.Flags().BoolVar(&flags.createTable, "output-create-table", false,
+		"Print only the CREATE TABLE statements of the tables, without headers or colors, so they can be run as SQL.")
+	cmd.Flags().BoolVar(&flags.html, "output-html", false,
+		"Print the schema as an HTML document, with a collapsible section for every table.")
+	cmd.Flags().BoolVar(&flags.markdown, "output-markdown", false,
+		"Print the schema as a Markdown document, with a heading and a SQL code block for every table.")
+	cmd.Flags().StringVar(&flags.title, "title", "",
+		"Title of the document printed with --output-html or --output-markdown. Markdown documents only have a title if it's set.")
+	cmd.Flags().StringVar(&flags.outputFile, "output-file", "",
+		"Write the document printed with --output-html, --output-markdown or --output-create-table to this file instead of stdout.")
 
 	return cmd
 }
+
+// filterTables returns the schemas of the given tables, in the order the
+// branch returned them. It returns an error if any of the tables doesn't
+// exist.
+func filterTables(schemas []*planetscale.Diff, tables []string) ([]*planetscale.Diff, error) {
+	wanted := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		wanted[t] = true
+	}
+
+	out := make([]*planetscale.Diff, 0, len(tables))
+	found := make(map[string]bool, len(tables))
+	for _, s := range schemas {
+		if wanted[s.Name] {
+			out = append(out, s)
+			found[s.Name] = true
+		}
+	}
+
+	var missing []string
+	for _, t := range tables {
+		if !found[t] {
+			missing = append(missing, t)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("table %s does not exist in the schema", printer.BoldBlue(strings.Join(missing, ", ")))
+	}
+
+	return out, nil
+}
+
+// ignoreTables returns the schemas without the given tables, along with the
+// tables that don't exist in the schema.
+func ignoreTables(schemas []*planetscale.Diff, tables []string) ([]*planetscale.Diff, []string) {
+	ignored := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		ignored[t] = true
+	}
+
+	out := make([]*planetscale.Diff, 0, len(schemas))
+	found := make(map[string]bool, len(tables))
+	for _, s := range schemas {
+		if ignored[s.Name] {
+			found[s.Name] = true
+			continue
+		}
+		out = append(out, s)
+	}
+
+	var missing []string
+	for _, t := range tables {
+		if !found[t] {
+			missing = append(missing, t)
+		}
+	}
+
+	return out, missing
+}
+
+// createTableStatements returns the CREATE TABLE statements of the tables,
+// each terminated by a semicolon, so they can be run as SQL.
+func createTableStatements(schemas []*planetscale.Diff) string {
+	var b strings.Builder
+	for _, s := range schemas {
+		fmt.Fprintf(&b, "%s;\n\n", strings.TrimSuffix(strings.TrimSpace(s.Raw), ";"))
+	}
+
+	return b.String()
+}
+
+// schemaMarkdown returns a Markdown document with a heading and a SQL code
+// block for every table. The document only has a top-level heading if title
+// isn't empty.
+func schemaMarkdown(title string, schemas []*planetscale.Diff) string {
+	var b strings.Builder
+	if title != "" {
+		fmt.Fprintf(&b, "# %s\n\n", title)
+	}
+	for _, s := range schemas {
+		fmt.Fprintf(&b, "## %s\n\n```sql\n%s\n```\n\n", s.Name, strings.TrimSpace(s.Raw))
+	}
+
+	return b.String()
+}
+
+const schemaHTMLStyle = `body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; }
+details { margin-bottom: 1em; }
+summary { cursor: pointer; font-weight: bold; }
+pre { background: #f6f8fa; border-radius: 6px; padding: 1em; overflow: auto; }`
+
+// schemaHTML returns a standalone HTML document with the schema of every
+// table in a collapsible section.
+func schemaHTML(title string, schemas []*planetscale.Diff) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(title))
+	fmt.Fprintf(&b, "<style>\n%s\n</style>\n", schemaHTMLStyle)
+	b.WriteString("</head>\n<body>\n")
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(title))
+	for _, s := range schemas {
+		fmt.Fprintf(&b, "<details>\n<summary>%s</summary>\n<pre>%s</pre>\n</details>\n",
+			html.EscapeString(s.Name), html.EscapeString(strings.TrimSpace(s.Raw)))
+	}
+	b.WriteString("</body>\n</html>\n")
+
+	return b.String()
+}
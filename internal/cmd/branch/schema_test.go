@@ -3,6 +3,9 @@ package branch
 import (
 	"bytes"
 	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/planetscale/cli/internal/cmdutil"
@@ -63,3 +66,232 @@ func TestBranchSchemaCmd(t *testing.T) {
 
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestBranchSchemaCmd_Table(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	branch := "feature"
+
+	res := []*ps.Diff{
+		{Name: "foo"},
+		{Name: "bar"},
+		{Name: "baz"},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		SchemaFn: func(ctx context.Context, req *ps.BranchSchemaRequest) ([]*ps.Diff, error) {
+			return res, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := SchemaCmd(ch)
+	cmd.SetArgs([]string{db, branch, "--table", "baz", "--table", "foo"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.JSONEquals, []*ps.Diff{res[0], res[2]})
+
+	buf.Reset()
+	cmd = SchemaCmd(ch)
+	cmd.SetArgs([]string{db, branch, "--table", "foo,qux"})
+	err = cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "table .*qux.* does not exist in the schema")
+	c.Assert(buf.String(), qt.Equals, "")
+}
+
+func TestBranchSchemaCmd_IgnoreTables(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	res := []*ps.Diff{
+		{Name: "users"},
+		{Name: "schema_migrations"},
+		{Name: "posts"},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		SchemaFn: func(ctx context.Context, req *ps.BranchSchemaRequest) ([]*ps.Diff, error) {
+			return res, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := SchemaCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "main", "--ignore-tables", "schema_migrations,ar_internal_metadata"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.JSONEquals, []*ps.Diff{res[0], res[2]})
+}
+
+func TestIgnoreTables(t *testing.T) {
+	c := qt.New(t)
+
+	schemas := []*ps.Diff{{Name: "users"}, {Name: "schema_migrations"}}
+
+	out, missing := ignoreTables(schemas, []string{"schema_migrations", "other"})
+	c.Assert(out, qt.DeepEquals, []*ps.Diff{schemas[0]})
+	c.Assert(missing, qt.DeepEquals, []string{"other"})
+}
+
+func TestBranchSchemaCmd_OutputCreateTable(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&buf)
+
+	res := []*ps.Diff{
+		{Name: "foo", Raw: "CREATE TABLE `foo` (\n  `id` int\n)"},
+		{Name: "bar", Raw: "CREATE TABLE `bar` (\n  `id` int\n);\n"},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		SchemaFn: func(ctx context.Context, req *ps.BranchSchemaRequest) ([]*ps.Diff, error) {
+			return res, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := SchemaCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "main", "--output-create-table"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.Equals, "CREATE TABLE `foo` (\n  `id` int\n);\n\nCREATE TABLE `bar` (\n  `id` int\n);\n\n")
+}
+
+func TestBranchSchemaCmd_OutputHTML(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&buf)
+
+	res := []*ps.Diff{
+		{Name: "foo", Raw: "CREATE TABLE `foo` (\n  `name` varchar(255) DEFAULT '<none>'\n)"},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		SchemaFn: func(ctx context.Context, req *ps.BranchSchemaRequest) ([]*ps.Diff, error) {
+			return res, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	cmd := SchemaCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "main", "--output-html", "--title", "Tables & views"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	out := buf.String()
+	c.Assert(out, qt.Contains, "<title>Tables &amp; views</title>")
+	c.Assert(out, qt.Contains, "<summary>foo</summary>")
+	c.Assert(out, qt.Contains, "<pre>CREATE TABLE `foo` (\n  `name` varchar(255) DEFAULT &#39;&lt;none&gt;&#39;\n)</pre>")
+	c.Assert(strings.HasSuffix(out, "</html>\n"), qt.IsTrue)
+}
+
+func TestBranchSchemaCmd_OutputMarkdown(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&buf)
+
+	res := []*ps.Diff{
+		{Name: "users", Raw: "CREATE TABLE `users` (\n  `id` int\n)\n"},
+		{Name: "posts", Raw: "CREATE TABLE `posts` (\n  `id` int\n)"},
+	}
+
+	svc := &mock.DatabaseBranchesService{
+		SchemaFn: func(ctx context.Context, req *ps.BranchSchemaRequest) ([]*ps.Diff, error) {
+			return res, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DatabaseBranches: svc,
+			}, nil
+		},
+	}
+
+	path := filepath.Join(c.TempDir(), "schema.md")
+
+	cmd := SchemaCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "main", "--output-markdown", "--title", "Schema", "--output-file", path})
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	out, err := ioutil.ReadFile(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(out), qt.Equals, "# Schema\n\n"+
+		"## users\n\n```sql\nCREATE TABLE `users` (\n  `id` int\n)\n```\n\n"+
+		"## posts\n\n```sql\nCREATE TABLE `posts` (\n  `id` int\n)\n```\n\n")
+	c.Assert(buf.String(), qt.Contains, "written to")
+}
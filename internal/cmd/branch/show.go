@@ -14,9 +14,10 @@ import (
 
 func ShowCmd(ch *cmdutil.Helper) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "show <source-database> <branch>",
-		Short: "Show a specific branch of a database",
-		Args:  cmdutil.RequiredArgs("source-database", "branch"),
+		Use:               "show <source-database> <branch>",
+		Short:             "Show a specific branch of a database",
+		Args:              cmdutil.RequiredArgs("source-database", "branch"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			source := args[0]
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"github.com/planetscale/cli/internal/cmdutil"
+
+	"github.com/spf13/cobra"
+)
+
+// ConfigCmd encapsulates the commands for inspecting the CLI configuration.
+func ConfigCmd(ch *cmdutil.Helper) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config <command>",
+		Short: "Inspect the configuration of the CLI",
+	}
+
+	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization,
+		"The organization for the current user")
+
+	cmd.AddCommand(ValidateCmd(ch))
+
+	return cmd
+}
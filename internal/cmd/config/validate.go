@@ -0,0 +1,221 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/config"
+	"github.com/planetscale/cli/internal/printer"
+
+	"github.com/spf13/cobra"
+)
+
+// ValidateCmd is the command for checking the configuration of the CLI.
+func ValidateCmd(ch *cmdutil.Helper) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration and credentials, and print the effective values",
+		Long: `Check the configuration files, the organization and the credentials the CLI
+uses, and print the effective configuration and the config files that were
+merged. The credentials are checked by listing the organizations they have
+access to.
+
+The organization and credentials can also be set with flags, environment
+variables or the project config file (.pscale.yml), so a missing config file
+is only an issue if they aren't set otherwise.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.DefaultConfigPath()
+			if err != nil {
+				return err
+			}
+			configFlag := false
+			if f := cmd.Flags().Lookup("config"); f != nil && f.Value.String() != "" {
+				path = f.Value.String()
+				configFlag = true
+			}
+
+			res := &validation{
+				ConfigFiles:      []string{},
+				Organization:     ch.Config.Organization,
+				ServiceTokenName: ch.Config.ServiceTokenName,
+				ServiceToken:     redact(ch.Config.ServiceToken),
+				Issues:           []string{},
+				Notes:            []string{},
+			}
+
+			// the config file is merged with the project config file, which
+			// is skipped if --config is set, like on every other command
+			fileIssues := []string{}
+			globalMissing := false
+			if _, err := ch.ConfigFS.NewFileConfig(path); err == nil {
+				res.ConfigFiles = append(res.ConfigFiles, path)
+			} else if errors.Is(err, fs.ErrNotExist) {
+				globalMissing = true
+			} else {
+				fileIssues = append(fileIssues, fmt.Sprintf("config file %s can't be read: %s", path, err))
+			}
+
+			if dir, err := config.ProjectConfigDir(); err == nil && !configFlag {
+				projectPath := filepath.Join(dir, config.ProjectConfigFile())
+				if _, err := ch.ConfigFS.NewFileConfig(projectPath); err == nil {
+					res.ConfigFiles = append(res.ConfigFiles, projectPath)
+				} else if !errors.Is(err, fs.ErrNotExist) {
+					fileIssues = append(fileIssues, fmt.Sprintf("project config file %s can't be read: %s", projectPath, err))
+				}
+			}
+
+			if ch.Config.Organization == "" {
+				res.Issues = append(res.Issues, "no organization is set, set 'org' in the config file or pass --org")
+			}
+
+			switch {
+			case ch.Config.ServiceToken != "" && ch.Config.ServiceTokenName != "":
+				res.AuthMethod = "service token"
+			case ch.Config.ServiceToken != "" || ch.Config.ServiceTokenName != "":
+				res.Issues = append(res.Issues, "both --service-token and --service-token-name need to be set to use a service token")
+			}
+			if res.AuthMethod == "" && ch.Config.AccessToken != "" {
+				res.AuthMethod = "access token"
+				res.AccessToken = redact(ch.Config.AccessToken)
+			}
+
+			if res.AuthMethod == "" {
+				res.Issues = append(res.Issues, "not authenticated, run 'pscale auth login' or set a service token")
+			} else {
+				res.Issues = append(res.Issues, checkCredentials(cmd, ch)...)
+			}
+
+			// the organization and credentials can come from flags, the
+			// environment or the project config file, as they often do in
+			// CI, so a missing config file is only an issue if they don't
+			if globalMissing {
+				if ch.Config.Organization == "" || res.AuthMethod == "" {
+					fileIssues = append(fileIssues, fmt.Sprintf("config file %s doesn't exist, run 'pscale org switch' to create it", path))
+				} else {
+					res.Notes = append(res.Notes, fmt.Sprintf("config file %s doesn't exist", path))
+				}
+			}
+			res.Issues = append(fileIssues, res.Issues...)
+
+			res.Valid = len(res.Issues) == 0
+
+			if ch.Printer.IsHuman() {
+				ch.Printer.Print(validationDetails(res))
+			} else if err := ch.Printer.PrintResource(res); err != nil {
+				return err
+			}
+
+			if !res.Valid {
+				return &cmdutil.Error{
+					Msg:      "the configuration is invalid",
+					ExitCode: 1,
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// checkCredentials verifies the credentials with the API and checks that
+// they have access to the configured organization.
+func checkCredentials(cmd *cobra.Command, ch *cmdutil.Helper) []string {
+	client, err := ch.Client()
+	if err != nil {
+		return []string{fmt.Sprintf("can't create an API client: %s", err)}
+	}
+
+	end := ch.Printer.PrintProgress("Checking credentials...")
+	defer end()
+
+	orgs, err := client.Organizations.List(cmd.Context())
+	if err != nil {
+		return []string{fmt.Sprintf("the API rejected the credentials: %s", cmdutil.HandleError(err))}
+	}
+	end()
+
+	if ch.Config.Organization == "" {
+		return nil
+	}
+
+	for _, org := range orgs {
+		if org.Name == ch.Config.Organization {
+			return nil
+		}
+	}
+
+	return []string{fmt.Sprintf("organization %s doesn't exist or isn't accessible with these credentials", ch.Config.Organization)}
+}
+
+// validation is the result of validating the configuration.
+type validation struct {
+	Valid            bool     `json:"valid"`
+	ConfigFiles      []string `json:"config_files" csv:"-"`
+	Organization     string   `json:"org"`
+	AuthMethod       string   `json:"auth_method"`
+	AccessToken      string   `json:"access_token,omitempty"`
+	ServiceTokenName string   `json:"service_token_name,omitempty"`
+	ServiceToken     string   `json:"service_token,omitempty"`
+	Issues           []string `json:"issues" csv:"-"`
+	Notes            []string `json:"notes" csv:"-"`
+}
+
+// redact hides all but the first characters of a secret.
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+
+	return secret[:4] + strings.Repeat("*", 8)
+}
+
+func validationDetails(v *validation) string {
+	value := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+
+	var buf strings.Builder
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Config files:"), value(strings.Join(v.ConfigFiles, ", ")))
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Organization:"), value(v.Organization))
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Authentication:"), value(v.AuthMethod))
+	if v.AccessToken != "" {
+		fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Access token:"), v.AccessToken)
+	}
+	if v.ServiceTokenName != "" || v.ServiceToken != "" {
+		fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Service token name:"), value(v.ServiceTokenName))
+		fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Service token:"), value(v.ServiceToken))
+	}
+	w.Flush() // nolint:errcheck
+
+	for _, note := range v.Notes {
+		fmt.Fprintf(&buf, "\n%s %s\n", printer.Bold("Note:"), note)
+	}
+
+	if v.Valid {
+		buf.WriteString("\nThe configuration is valid.\n")
+		return buf.String()
+	}
+
+	buf.WriteString("\n" + printer.Bold("Issues:") + "\n")
+	for _, issue := range v.Issues {
+		fmt.Fprintf(&buf, "  - %s\n", issue)
+	}
+
+	return buf.String()
+}
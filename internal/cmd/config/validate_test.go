@@ -0,0 +1,213 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/config"
+	"github.com/planetscale/cli/internal/mock"
+	"github.com/planetscale/cli/internal/printer"
+	"github.com/planetscale/cli/internal/testutil"
+
+	qt "github.com/frankban/quicktest"
+	ps "github.com/planetscale/planetscale-go/planetscale"
+)
+
+func TestConfig_ValidateCmd(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	path, err := config.DefaultConfigPath()
+	c.Assert(err, qt.IsNil)
+
+	svc := &mock.OrganizationsService{
+		ListFn: func(ctx context.Context) ([]*ps.Organization, error) {
+			return []*ps.Organization{{Name: "planetscale"}}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+			AccessToken:  "pscale_oauth_123456789",
+		},
+		ConfigFS: config.NewConfigFS(testutil.MemFS{
+			path: &fstest.MapFile{Data: []byte("org: planetscale\n")},
+		}),
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Organizations: svc,
+			}, nil
+		},
+	}
+
+	cmd := ValidateCmd(ch)
+	err = cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListFnInvoked, qt.IsTrue)
+	c.Assert(buf.String(), qt.JSONEquals, map[string]interface{}{
+		"valid":        true,
+		"config_files": []string{path},
+		"org":          "planetscale",
+		"auth_method":  "access token",
+		"access_token": "psca********",
+		"issues":       []string{},
+		"notes":        []string{},
+	})
+}
+
+func TestConfig_ValidateCmd_Invalid(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	svc := &mock.OrganizationsService{
+		ListFn: func(ctx context.Context) ([]*ps.Organization, error) {
+			return []*ps.Organization{{Name: "other"}}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization:     "planetscale",
+			ServiceTokenName: "token-name",
+		},
+		ConfigFS: config.NewConfigFS(testutil.MemFS{}),
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Organizations: svc,
+			}, nil
+		},
+	}
+
+	cmd := ValidateCmd(ch)
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "the configuration is invalid")
+	c.Assert(svc.ListFnInvoked, qt.IsFalse)
+
+	var res validation
+	c.Assert(json.Unmarshal(buf.Bytes(), &res), qt.IsNil)
+	c.Assert(res.Valid, qt.IsFalse)
+	c.Assert(res.Issues, qt.HasLen, 3)
+	c.Assert(res.Issues[0], qt.Matches, "config file .* doesn't exist.*")
+	c.Assert(res.Issues[1], qt.Matches, "both --service-token and --service-token-name .*")
+	c.Assert(res.Issues[2], qt.Matches, "not authenticated.*")
+}
+
+func TestConfig_ValidateCmd_InaccessibleOrg(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	path, err := config.DefaultConfigPath()
+	c.Assert(err, qt.IsNil)
+
+	svc := &mock.OrganizationsService{
+		ListFn: func(ctx context.Context) ([]*ps.Organization, error) {
+			return []*ps.Organization{{Name: "other"}}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization:     "planetscale",
+			ServiceTokenName: "token-name",
+			ServiceToken:     "secret",
+		},
+		ConfigFS: config.NewConfigFS(testutil.MemFS{
+			path: &fstest.MapFile{Data: []byte("org: planetscale\n")},
+		}),
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Organizations: svc,
+			}, nil
+		},
+	}
+
+	cmd := ValidateCmd(ch)
+	err = cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "the configuration is invalid")
+	c.Assert(buf.String(), qt.JSONEquals, map[string]interface{}{
+		"valid":              false,
+		"config_files":       []string{path},
+		"org":                "planetscale",
+		"auth_method":        "service token",
+		"service_token_name": "token-name",
+		"service_token":      "******",
+		"issues": []string{
+			"organization planetscale doesn't exist or isn't accessible with these credentials",
+		},
+		"notes": []string{},
+	})
+}
+
+func TestConfig_ValidateCmd_NoConfigFile(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	path, err := config.DefaultConfigPath()
+	c.Assert(err, qt.IsNil)
+
+	svc := &mock.OrganizationsService{
+		ListFn: func(ctx context.Context) ([]*ps.Organization, error) {
+			return []*ps.Organization{{Name: "planetscale"}}, nil
+		},
+	}
+
+	// the organization and service token come from the environment, as in CI
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization:     "planetscale",
+			ServiceTokenName: "token-name",
+			ServiceToken:     "secret",
+		},
+		ConfigFS: config.NewConfigFS(testutil.MemFS{}),
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Organizations: svc,
+			}, nil
+		},
+	}
+
+	cmd := ValidateCmd(ch)
+	err = cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.JSONEquals, map[string]interface{}{
+		"valid":              true,
+		"config_files":       []string{},
+		"org":                "planetscale",
+		"auth_method":        "service token",
+		"service_token_name": "token-name",
+		"service_token":      "******",
+		"issues":             []string{},
+		"notes": []string{
+			"config file " + path + " doesn't exist",
+		},
+	})
+}
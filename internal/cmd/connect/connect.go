@@ -9,9 +9,12 @@ import (
 	"os/exec"
 	"os/signal"
 	"runtime"
+	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/config"
 	"github.com/planetscale/cli/internal/printer"
 	"github.com/planetscale/cli/internal/promptutil"
 	"github.com/planetscale/cli/internal/proxyutil"
@@ -25,18 +28,29 @@ import (
 func ConnectCmd(ch *cmdutil.Helper) *cobra.Command {
 	var flags struct {
 		port                string
+		portRange           string
 		host                string
 		remoteAddr          string
 		execCommand         string
 		execCommandProtocol string
 		execCommandEnvURL   string
+		connStringEnv       []string
+		bindAll             bool
+		tlsServerName       string
+		printMySQLCommand   bool
+		noTunnelFile        bool
+		onDisconnect        string
+		probeURL            string
+		probeInterval       time.Duration
+		probeTimeout        time.Duration
 	}
 
 	cmd := &cobra.Command{
 		Use: "connect [database] [branch]",
 		// we only require database, because we deduct branch automatically
-		Args:  cmdutil.RequiredArgs("database"),
-		Short: "Create a secure connection to a database and branch for a local client",
+		Args:              cmdutil.RequiredArgs("database"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		Short:             "Create a secure connection to a database and branch for a local client",
 		Example: `The connect subcommand establishes a secure connection between your host and PlanetScale. 
 
 By default, if no branch names are given and there is only one branch, it
@@ -48,14 +62,79 @@ If there are multiple branches for the given database, you'll be prompted to
 choose one. To connect to a specific branch, pass the branch as a second
 argument:
 
-  pscale connect mydatabase mybranch`,
+  pscale connect mydatabase mybranch
+
+The TLS server name used for SNI and to verify the server certificate is the
+access host of the branch, even when --remote-addr is set. If the remote
+address routes connections on a different SNI name, or presents a certificate
+for a different name, set it with --tls-server-name:
+
+  pscale connect mydatabase mybranch --remote-addr lb.example.com:3307 --tls-server-name db.example.com
+
+Inside a project, the host and port of the proxy are written to
+.pscale-tunnel.json in the project root while the proxy is running, so
+development tools can pick them up. The project root is the directory of
+.pscale.yml, or else the root of the Git repository. With several proxies
+running in a project, the file has the address of the one started last. Use
+--no-tunnel-file to not write it.
+
+To take action once the proxy stops, such as restarting the services that
+depend on it, pass a command to --on-disconnect. It runs with 'sh -c', or
+'cmd /C' on Windows, with PSCALE_DISCONNECT_REASON set to "user" if the proxy
+was stopped (ctrl-c, SIGTERM or the end of --execute) and "error" if it failed.
+It only runs if the proxy was up, not if it failed to start. The proxy keeps
+running when connections to the database drop and has no timeout of its own,
+so there is no "timeout" reason:
+
+  pscale connect mydatabase mybranch --on-disconnect 'systemctl restart myapp'
+
+To only start the proxy once a dependency is up, such as in an init
+container, pass its health check to --startup-probe-url. It's checked every
+--startup-probe-interval until it responds with a 2xx status code, for at
+most --startup-probe-timeout:
+
+  pscale connect mydatabase mybranch --startup-probe-url http://localhost:8080/healthz`,
 		PersistentPreRunE: cmdutil.CheckAuthentication(ch.Config),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx, cancel := context.WithCancel(cmd.Context())
+			// stop the proxy on SIGTERM too, so the tunnel file is removed
+			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
 			defer cancel()
 
 			database := args[0]
 
+			if flags.bindAll {
+				if cmd.Flags().Changed("host") {
+					return errors.New("--bind-all and --host cannot be used together")
+				}
+				flags.host = "0.0.0.0"
+			}
+
+			if flags.portRange != "" && cmd.Flags().Changed("port") {
+				return errors.New("--port and --port-range cannot be used together")
+			}
+
+			var portStart, portEnd int
+			if flags.portRange != "" {
+				var err error
+				portStart, portEnd, err = parsePortRange(flags.portRange)
+				if err != nil {
+					return err
+				}
+			}
+
+			if flags.probeURL == "" && (cmd.Flags().Changed("startup-probe-interval") || cmd.Flags().Changed("startup-probe-timeout")) {
+				return errors.New("--startup-probe-interval and --startup-probe-timeout can only be used with --startup-probe-url")
+			}
+			if flags.probeURL != "" && flags.probeInterval <= 0 {
+				return errors.New("--startup-probe-interval must be greater than 0")
+			}
+
+			if flags.printMySQLCommand {
+				if format := ch.Printer.Format(); !format.IsHuman() {
+					return fmt.Errorf("--print-mysql-command can't be used with the output format %q", format.String())
+				}
+			}
+
 			client, err := ch.Config.NewClientFromConfig()
 			if err != nil {
 				return err
@@ -74,7 +153,7 @@ argument:
 			}
 
 			// check whether database and branch exist
-			_, err = client.DatabaseBranches.Get(ctx, &planetscale.GetDatabaseBranchRequest{
+			dbBranch, err := client.DatabaseBranches.Get(ctx, &planetscale.GetDatabaseBranchRequest{
 				Organization: ch.Config.Organization,
 				Database:     database,
 				Branch:       branch,
@@ -89,28 +168,81 @@ argument:
 				}
 			}
 
+			// the warning is written to stderr for every format, as the
+			// printer discards it for formats other than human
+			if flags.bindAll {
+				fmt.Fprintf(os.Stderr, "%s the proxy listens on all network interfaces. Anyone who can reach this host can connect to %s/%s without credentials.\n\n",
+					printer.BoldRed("WARNING:"), printer.BoldBlue(database), printer.BoldBlue(branch))
+			}
+
+			if flags.portRange != "" {
+				port, err := pickPort(flags.host, portStart, portEnd)
+				if err != nil {
+					return err
+				}
+				flags.port = strconv.Itoa(port)
+				ch.Printer.Printf("Using port %s from the range %s.\n", printer.BoldBlue(flags.port), flags.portRange)
+			}
+
 			localAddr := net.JoinHostPort(flags.host, flags.port)
 
+			var certSource proxy.CertSource = proxyutil.NewRemoteCertSource(client)
+			remoteAddr := flags.remoteAddr
+			if flags.tlsServerName != "" {
+				// the proxy dials the access host unless a remote address is
+				// set, so keep dialing it when only the server name changes
+				certSource = proxyutil.NewServerNameCertSource(certSource, flags.tlsServerName)
+				if remoteAddr == "" {
+					remoteAddr = net.JoinHostPort(dbBranch.AccessHostURL, strconv.Itoa(proxyutil.ProxyPort))
+				}
+			}
+
 			proxyOpts := proxy.Options{
-				CertSource: proxyutil.NewRemoteCertSource(client),
+				CertSource: certSource,
 				LocalAddr:  localAddr,
-				RemoteAddr: flags.remoteAddr,
+				RemoteAddr: remoteAddr,
 				Instance:   fmt.Sprintf("%s/%s/%s", ch.Config.Organization, database, branch),
 				Logger:     cmdutil.NewZapLogger(ch.Debug()),
 			}
 
+			if flags.probeURL != "" {
+				end := ch.Printer.PrintProgress(fmt.Sprintf("Waiting for %s to become healthy...", printer.BoldBlue(flags.probeURL)))
+				err := waitHealthy(ctx, flags.probeURL, flags.probeInterval, flags.probeTimeout)
+				end()
+				if err != nil {
+					return err
+				}
+			}
+
+			var tunnel *tunnelFile
+			if !flags.noTunnelFile {
+				// outside of a project there is no root to write the file to
+				if dir, err := config.ProjectConfigDir(); err == nil {
+					tunnel = newTunnelFile(dir)
+				}
+			}
+
 			proxyReady := make(chan string, 1)
 
 			var executeCh chan error
 			if flags.execCommand != "" {
 				executeCh = make(chan error, 1)
 
+				envNames := flags.connStringEnv
+				if cmd.Flags().Changed("execute-env-url") {
+					if cmd.Flags().Changed("connection-string-env") {
+						envNames = append(envNames, flags.execCommandEnvURL)
+					} else {
+						envNames = []string{flags.execCommandEnvURL}
+					}
+				}
+
 				go func() {
 					err := runCommand(
 						ctx,
 						flags.execCommand,
 						flags.execCommandProtocol,
-						flags.execCommandEnvURL,
+						envNames,
 						database,
 						branch,
 						proxyReady,
@@ -123,16 +255,27 @@ argument:
 				}()
 			}
 
-			err = runProxy(ctx, ch, proxyOpts, database, branch, proxyReady)
-			if err != nil {
-				if isAddrInUse(err) {
-					ch.Printer.Printf("Tried address %s, but it's already in use. Picking up a random port ...\n", localAddr)
-					proxyOpts.LocalAddr = net.JoinHostPort(flags.host, "0")
-					return runProxy(ctx, ch, proxyOpts, database, branch, proxyReady)
+			// the proxy only disconnects if it was up, so the --on-disconnect
+			// command isn't run if it failed to start
+			started, err := runProxy(ctx, ch, proxyOpts, database, branch, flags.printMySQLCommand, tunnel, proxyReady)
+			// a random port would be outside of the range
+			if err != nil && isAddrInUse(err) && flags.portRange == "" {
+				ch.Printer.Printf("Tried address %s, but it's already in use. Picking up a random port ...\n", localAddr)
+				proxyOpts.LocalAddr = net.JoinHostPort(flags.host, "0")
+				started, err = runProxy(ctx, ch, proxyOpts, database, branch, flags.printMySQLCommand, tunnel, proxyReady)
+				if started {
+					runOnDisconnect(ctx, flags.onDisconnect, err, database, branch)
 				}
 				return err
 			}
 
+			if started {
+				runOnDisconnect(ctx, flags.onDisconnect, err, database, branch)
+			}
+			if err != nil {
+				return err
+			}
+
 			// if the user enabled the --execute flag, make sure to return the
 			// error message and status from that command
 			if executeCh != nil {
@@ -145,31 +288,64 @@ argument:
 
 	cmd.PersistentFlags().StringVar(&ch.Config.Organization, "org", ch.Config.Organization, "The organization for the current user")
 	cmd.PersistentFlags().StringVar(&flags.host, "host", "127.0.0.1", "Local host to bind and listen for connections")
-	cmd.PersistentFlags().StringVar(&flags.port, "port", "3306", "Local port to bind and listen for connections")
+	cmd.PersistentFlags().BoolVar(&flags.bindAll, "bind-all", false,
+		"Listen on all network interfaces (0.0.0.0) instead of 127.0.0.1. This exposes the database to your network.")
+	cmd.PersistentFlags().StringVar(&flags.port, "port", "3306", "Local port to bind and listen for connections. Use 0 to pick a free port")
+	cmd.PersistentFlags().StringVar(&flags.portRange, "port-range", "",
+		"Range of local ports, in the form of <start>-<end>, to pick a free port from at random instead of using --port")
 	cmd.PersistentFlags().StringVar(&flags.remoteAddr, "remote-addr", "",
 		"PlanetScale Database remote network address. By default the remote address is populated automatically from the PlanetScale API.")
+	cmd.PersistentFlags().StringVar(&flags.tlsServerName, "tls-server-name", "",
+		"Server name to send (SNI) and verify in the TLS handshake. By default it's the access host of the branch.")
 	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
-	cmd.PersistentFlags().StringVar(&flags.execCommand, "execute", "", "Run this command after successfully connecting to the database.")
+	cmd.PersistentFlags().StringVarP(&flags.execCommand, "execute", "e", "", "Run this command after successfully connecting to the database.")
 	cmd.PersistentFlags().StringVar(&flags.execCommandProtocol, "execute-protocol",
 		"mysql2", "Protocol for the exposed URL (by default DATABASE_URL) value in execute")
 	cmd.PersistentFlags().StringVar(&flags.execCommandEnvURL, "execute-env-url", "DATABASE_URL",
 		"Environment variable name that contains the exposed Database URL.")
+	cmd.PersistentFlags().StringSliceVar(&flags.connStringEnv, "connection-string-env", []string{"DATABASE_URL"},
+		"Environment variable names that contain the exposed Database URL in execute. Can be given multiple times.")
+	cmd.PersistentFlags().BoolVar(&flags.printMySQLCommand, "print-mysql-command", false,
+		"Once connected, print a mysql command to connect to the local address instead of the connection message.")
+	cmd.PersistentFlags().StringVar(&flags.onDisconnect, "on-disconnect", "",
+		"Run this command with 'sh -c' ('cmd /C' on Windows) once the proxy stops. PSCALE_DISCONNECT_REASON is set to user or error.")
+	cmd.PersistentFlags().StringVar(&flags.probeURL, "startup-probe-url", "",
+		"Wait for this URL to respond with a 2xx status code before starting the proxy.")
+	cmd.PersistentFlags().DurationVar(&flags.probeInterval, "startup-probe-interval", 2*time.Second,
+		"How often to check --startup-probe-url.")
+	cmd.PersistentFlags().DurationVar(&flags.probeTimeout, "startup-probe-timeout", time.Minute,
+		"How long to wait for --startup-probe-url to become healthy.")
+	cmd.PersistentFlags().BoolVar(&flags.noTunnelFile, "no-tunnel-file", false,
+		"Don't write the local address to .pscale-tunnel.json in the project root.")
 	return cmd
 }
 
-// runProxy runs the sql-proxy with the given options.
+// runProxy runs the sql-proxy with the given options. If mysqlCommand is
+// true, the mysql command to connect to the proxy is printed once it's ready.
+// If tunnel is set, the local address is written to it while the proxy is
+// running. started reports whether the proxy was listening for connections
+// before it stopped.
 func runProxy(
 	ctx context.Context,
 	ch *cmdutil.Helper,
 	proxyOpts proxy.Options,
 	database, branch string,
+	mysqlCommand bool,
+	tunnel *tunnelFile,
 	ready chan string,
-) error {
+) (started bool, err error) {
 	p, err := proxy.NewClient(proxyOpts)
 	if err != nil {
-		return fmt.Errorf("couldn't create proxy client: %s", err)
+		return false, fmt.Errorf("couldn't create proxy client: %s", err)
 	}
 
+	defer func() {
+		if err := tunnel.remove(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed removing tunnel file: %s\n", err)
+		}
+	}()
+
+	listening := make(chan struct{})
 	go func(ready chan string) {
 		// this is blocking and will only return once p.Run() below is
 		// invoked
@@ -178,21 +354,68 @@ func runProxy(
 			fmt.Fprintf(os.Stderr, "failed getting local addr: %s\n", err)
 			return
 		}
+		close(listening)
+
+		if mysqlCommand {
+			ch.Printer.Println(mysqlCommandLine(addr, database))
+		} else if ch.Printer.IsHuman() {
+			ch.Printer.Printf("Secure connection to database %s and branch %s is established!.\n\nLocal address to connect your application: %s (press ctrl-c to quit)\n",
+				printer.BoldBlue(database),
+				printer.BoldBlue(branch),
+				printer.BoldBlue(addr.String()),
+			)
+		} else if err := ch.Printer.PrintResource(toLocalAddress(addr, database, branch)); err != nil {
+			fmt.Fprintf(os.Stderr, "failed printing local addr: %s\n", err)
+		}
 
-		ch.Printer.Printf("Secure connection to database %s and branch %s is established!.\n\nLocal address to connect your application: %s (press ctrl-c to quit)\n",
-			printer.BoldBlue(database),
-			printer.BoldBlue(branch),
-			printer.BoldBlue(addr.String()),
-		)
+		if tunnel != nil {
+			writeTunnelFile(ch, tunnel, toLocalAddress(addr, database, branch))
+		}
 		ready <- addr.String()
 	}(ready)
 
-	return p.Run(ctx)
+	err = p.Run(ctx)
+
+	// Run only returns nil once it was listening. Otherwise, LocalAddr
+	// returned right when the listener was up, while stopping the proxy
+	// waits for its connections for at least 100ms, so listening is closed
+	// by now if the proxy was started.
+	select {
+	case <-listening:
+		started = true
+	default:
+		started = err == nil
+	}
+
+	return started, err
+}
+
+// writeTunnelFile writes the local address to the tunnel file. Its path is
+// printed to stderr, so it doesn't mix with the output of the command.
+func writeTunnelFile(ch *cmdutil.Helper, tunnel *tunnelFile, la *localAddress) {
+	if err := tunnel.write(la); err != nil {
+		fmt.Fprintf(os.Stderr, "failed writing tunnel file: %s\n", err)
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Local address written to %s\n", tunnel.path)
+	if ignored, err := gitIgnored(tunnel.path); err == nil && !ignored {
+		ch.Printer.Printf("%s %s isn't ignored by Git, add it to your .gitignore file.\n",
+			printer.BoldRed("WARNING:"), tunnelFileName)
+	}
+}
+
+// mysqlCommandLine returns the mysql command to connect to the database
+// through the proxy listening on addr. The proxy authenticates the
+// connection, so no password is needed.
+func mysqlCommandLine(addr net.Addr, database string) string {
+	la := toLocalAddress(addr, database, "")
+	return fmt.Sprintf("mysql -h %s -P %d -u %s %s", la.Host, la.Port, la.Username, database)
 }
 
 // runCommand runs the given command with several environment variables exposed
 // to the command.
-func runCommand(ctx context.Context, command, protocol, databaseEnvURL, database, branch string, ready chan string) error {
+func runCommand(ctx context.Context, command, protocol string, databaseEnvURLs []string, database, branch string, ready chan string) error {
 	args, err := shellwords.Parse(command)
 	if err != nil {
 		return fmt.Errorf("failed to parse command, not running: %s", err)
@@ -202,13 +425,15 @@ func runCommand(ctx context.Context, command, protocol, databaseEnvURL, database
 	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, os.Kill)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd := exec.Command(args[0], args[1:]...)
 	cmd.Env = os.Environ()
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
-	connStr := fmt.Sprintf("%s=%s://root@%s/%s", databaseEnvURL, protocol, addr, database)
-	cmd.Env = append(cmd.Env, connStr)
+	for _, name := range databaseEnvURLs {
+		connStr := fmt.Sprintf("%s=%s://root@%s/%s", name, protocol, addr, database)
+		cmd.Env = append(cmd.Env, connStr)
+	}
 
 	hostEnv := fmt.Sprintf("PLANETSCALE_DATABASE_HOST=%s", addr)
 	cmd.Env = append(cmd.Env, hostEnv)
@@ -219,7 +444,7 @@ func runCommand(ctx context.Context, command, protocol, databaseEnvURL, database
 	branchName := fmt.Sprintf("PLANETSCALE_BRANCH_NAME=%s", branch)
 	cmd.Env = append(cmd.Env, branchName)
 
-	err = cmd.Run()
+	err = runAndTerminate(ctx, cmd)
 	if err == nil {
 		return nil
 	}
@@ -235,6 +460,99 @@ func runCommand(ctx context.Context, command, protocol, databaseEnvURL, database
 	return err
 }
 
+// runOnDisconnect runs the --on-disconnect command, if it's set, once the
+// proxy running with ctx stopped with err. Its failure is only reported, so
+// the error of the proxy is returned in any case.
+func runOnDisconnect(ctx context.Context, command string, err error, database, branch string) {
+	if command == "" {
+		return
+	}
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	cmd := exec.Command(shell, flag, command)
+	cmd.Env = append(os.Environ(),
+		"PSCALE_DISCONNECT_REASON="+disconnectReason(ctx, err),
+		"PLANETSCALE_DATABASE_NAME="+database,
+		"PLANETSCALE_BRANCH_NAME="+branch,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "running command with --on-disconnect has failed: %s\n", err)
+	}
+}
+
+// disconnectReason returns why the proxy running with ctx stopped with err.
+// ctx is cancelled by ctrl-c, SIGTERM and the end of --execute, which stop
+// the proxy on purpose even if its shutdown fails, for example because
+// connections are still open. There is no "timeout" reason: the proxy
+// handles dropped and timed out connections without stopping, and doesn't
+// stop on its own once it's up.
+func disconnectReason(ctx context.Context, err error) string {
+	if ctx.Err() != nil || err == nil {
+		return "user"
+	}
+
+	return "error"
+}
+
+// runAndTerminate runs the command and waits for it to exit. If ctx is done
+// first, the command is asked to stop with SIGTERM so it can shut down
+// cleanly, falling back to killing it where SIGTERM isn't supported.
+func runAndTerminate(ctx context.Context, cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+			_ = cmd.Process.Kill()
+		}
+		return <-done
+	}
+}
+
+// localAddress is the address the proxy listens on, printed for non-human
+// output formats so scripts can pick up the port.
+type localAddress struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Database string `json:"database"`
+	Branch   string `json:"branch"`
+	Username string `json:"username"`
+}
+
+func (l *localAddress) MarshalCSVValue() interface{} {
+	return []*localAddress{l}
+}
+
+func toLocalAddress(addr net.Addr, database, branch string) *localAddress {
+	la := &localAddress{
+		Host:     addr.String(),
+		Database: database,
+		Branch:   branch,
+		Username: "root",
+	}
+
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+		la.Host = tcpAddr.IP.String()
+		la.Port = tcpAddr.Port
+	}
+
+	return la
+}
+
 // isAddrInUse returns an error if the error indicates that the given address
 // is already in use. Becaue different OS return different error messages, we
 // try to get the underlying error.
@@ -0,0 +1,202 @@
+package connect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/config"
+	"github.com/planetscale/cli/internal/printer"
+	"github.com/planetscale/sql-proxy/proxy"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/zap"
+)
+
+// stubCertSource returns an empty certificate, which is enough for the proxy
+// to start listening.
+type stubCertSource struct{}
+
+func (stubCertSource) Cert(ctx context.Context, org, db, branch string) (*proxy.Cert, error) {
+	return &proxy.Cert{AccessHost: "localhost"}, nil
+}
+
+func TestRunProxy_RandomPort(t *testing.T) {
+	c := qt.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(ioutil.Discard)
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config:  &config.Config{Organization: "planetscale"},
+	}
+
+	start := func() string {
+		ready := make(chan string, 1)
+		opts := proxy.Options{
+			CertSource: stubCertSource{},
+			LocalAddr:  "127.0.0.1:0",
+			Instance:   "planetscale/mydb/main",
+			Logger:     zap.NewNop(),
+		}
+
+		go runProxy(ctx, ch, opts, "mydb", "main", false, nil, ready) // nolint:errcheck
+		return <-ready
+	}
+
+	addr1, addr2 := start(), start()
+
+	_, port1, err := net.SplitHostPort(addr1)
+	c.Assert(err, qt.IsNil)
+	_, port2, err := net.SplitHostPort(addr2)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(port1, qt.Not(qt.Equals), "0")
+	c.Assert(port2, qt.Not(qt.Equals), "0")
+	c.Assert(port1, qt.Not(qt.Equals), port2)
+}
+
+func TestRunProxy_TunnelFile(t *testing.T) {
+	c := qt.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(ioutil.Discard)
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config:  &config.Config{Organization: "planetscale"},
+	}
+
+	dir := c.TempDir()
+	tunnel := newTunnelFile(dir)
+
+	opts := proxy.Options{
+		CertSource: stubCertSource{},
+		LocalAddr:  "127.0.0.1:0",
+		Instance:   "planetscale/mydb/main",
+		Logger:     zap.NewNop(),
+	}
+
+	ready := make(chan string, 1)
+	done := make(chan error, 1)
+	go func() {
+		_, err := runProxy(ctx, ch, opts, "mydb", "main", false, tunnel, ready)
+		done <- err
+	}()
+	addr := <-ready
+
+	out, err := ioutil.ReadFile(filepath.Join(dir, ".pscale-tunnel.json"))
+	c.Assert(err, qt.IsNil)
+
+	_, port, err := net.SplitHostPort(addr)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(out), qt.JSONEquals, map[string]interface{}{
+		"host":     "127.0.0.1",
+		"port":     json.Number(port),
+		"database": "mydb",
+		"branch":   "main",
+	})
+
+	cancel()
+	<-done
+
+	_, err = os.Stat(filepath.Join(dir, ".pscale-tunnel.json"))
+	c.Assert(os.IsNotExist(err), qt.IsTrue)
+}
+
+// failingCertSource fails to return a certificate, so the proxy never starts
+// listening.
+type failingCertSource struct{}
+
+func (failingCertSource) Cert(ctx context.Context, org, db, branch string) (*proxy.Cert, error) {
+	return nil, errors.New("couldn't get certificate")
+}
+
+func TestRunProxy_Started(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(ioutil.Discard)
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config:  &config.Config{Organization: "planetscale"},
+	}
+
+	opts := proxy.Options{
+		CertSource: failingCertSource{},
+		LocalAddr:  "127.0.0.1:0",
+		Instance:   "planetscale/mydb/main",
+		Logger:     zap.NewNop(),
+	}
+
+	started, err := runProxy(context.Background(), ch, opts, "mydb", "main", false, nil, make(chan string, 1))
+	c.Assert(err, qt.ErrorMatches, ".*couldn't get certificate")
+	c.Assert(started, qt.IsFalse)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts.CertSource = stubCertSource{}
+	ready := make(chan string, 1)
+	go func() {
+		<-ready
+		cancel()
+	}()
+
+	started, err = runProxy(ctx, ch, opts, "mydb", "main", false, nil, ready)
+	c.Assert(err, qt.IsNil)
+	c.Assert(started, qt.IsTrue)
+}
+
+func TestMySQLCommandLine(t *testing.T) {
+	c := qt.New(t)
+
+	addr := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 3306}
+	c.Assert(mysqlCommandLine(addr, "mydb"), qt.Equals, "mysql -h 127.0.0.1 -P 3306 -u root mydb")
+}
+
+func TestDisconnectReason(t *testing.T) {
+	c := qt.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Assert(disconnectReason(ctx, errors.New("couldn't get certificate")), qt.Equals, "error")
+
+	// the proxy was stopped, even though its shutdown failed
+	cancel()
+	c.Assert(disconnectReason(ctx, nil), qt.Equals, "user")
+	c.Assert(disconnectReason(ctx, errors.New("error during shutdown: 2 active connections still exist")), qt.Equals, "user")
+}
+
+func TestRunOnDisconnect(t *testing.T) {
+	c := qt.New(t)
+
+	path := filepath.Join(c.TempDir(), "reason")
+	command := `echo "$PSCALE_DISCONNECT_REASON $PLANETSCALE_DATABASE_NAME/$PLANETSCALE_BRANCH_NAME" > ` + path
+	if runtime.GOOS == "windows" {
+		command = `echo %PSCALE_DISCONNECT_REASON% %PLANETSCALE_DATABASE_NAME%/%PLANETSCALE_BRANCH_NAME%> ` + path
+	}
+	runOnDisconnect(context.Background(), command, errors.New("listen failed"), "mydb", "main")
+
+	out, err := ioutil.ReadFile(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.TrimSpace(string(out)), qt.Equals, "error mydb/main")
+}
@@ -0,0 +1,58 @@
+package connect
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parsePortRange parses a port range in the form of <start>-<end>.
+func parsePortRange(s string) (start, end int, err error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid port range %q, must be in the form of <start>-<end>", s)
+	}
+
+	start, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid start of port range %q: %s", s, err)
+	}
+
+	end, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid end of port range %q: %s", s, err)
+	}
+
+	if start < 1 || end > 65535 {
+		return 0, 0, fmt.Errorf("invalid port range %q, ports must be between 1 and 65535", s)
+	}
+
+	if start > end {
+		return 0, 0, fmt.Errorf("invalid port range %q, the start is greater than the end", s)
+	}
+
+	return start, end, nil
+}
+
+// pickPort returns a random port between start and end, inclusive, that's
+// free to listen on at host. The port is released before returning, so
+// another process could still take it before the proxy listens on it.
+func pickPort(host string, start, end int) (int, error) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	for _, i := range r.Perm(end - start + 1) {
+		port := start + i
+		l, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+		if err != nil {
+			continue
+		}
+		l.Close()
+
+		return port, nil
+	}
+
+	return 0, fmt.Errorf("no port is available in the range %d-%d", start, end)
+}
@@ -0,0 +1,65 @@
+package connect
+
+import (
+	"net"
+	"strconv"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParsePortRange(t *testing.T) {
+	tests := []struct {
+		in         string
+		start, end int
+		err        string
+	}{
+		{in: "3306-3310", start: 3306, end: 3310},
+		{in: "4000-4000", start: 4000, end: 4000},
+		{in: "3306", err: `invalid port range "3306", must be in the form of <start>-<end>`},
+		{in: "a-3310", err: `invalid start of port range "a-3310": .*`},
+		{in: "3306-b", err: `invalid end of port range "3306-b": .*`},
+		{in: "0-10", err: `invalid port range "0-10", ports must be between 1 and 65535`},
+		{in: "3310-3306", err: `invalid port range "3310-3306", the start is greater than the end`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			c := qt.New(t)
+
+			start, end, err := parsePortRange(tt.in)
+			if tt.err != "" {
+				c.Assert(err, qt.ErrorMatches, tt.err)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Assert(start, qt.Equals, tt.start)
+			c.Assert(end, qt.Equals, tt.end)
+		})
+	}
+}
+
+func TestPickPort(t *testing.T) {
+	c := qt.New(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	defer l.Close()
+
+	taken := l.Addr().(*net.TCPAddr).Port
+
+	_, err = pickPort("127.0.0.1", taken, taken)
+	c.Assert(err, qt.ErrorMatches, "no port is available in the range "+strconv.Itoa(taken)+"-"+strconv.Itoa(taken))
+
+	// the port next to the taken one is picked, as long as it's free
+	if taken == 65535 {
+		c.Skip("no free port next to the taken one")
+	}
+
+	port, err := pickPort("127.0.0.1", taken, taken+1)
+	if err != nil {
+		c.Skip("the port next to the taken one is in use")
+	}
+	c.Assert(port, qt.Equals, taken+1)
+}
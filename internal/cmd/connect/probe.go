@@ -0,0 +1,65 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// waitHealthy polls url every interval until it responds with a 2xx status
+// code. It returns an error if url isn't healthy within timeout.
+func waitHealthy(ctx context.Context, url string, interval, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// a request shouldn't take longer than the time between two of them
+	client := &http.Client{Timeout: interval}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// the error of the last check that wasn't cut short by the timeout
+	lastErr := context.DeadlineExceeded
+	for {
+		err := probe(ctx, client, url)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() == nil {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %s to become healthy: %s", url, lastErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe sends a GET request to url and returns an error unless the response
+// has a 2xx status code.
+func probe(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// drain the body so the connection can be reused
+	io.Copy(ioutil.Discard, res.Body) // nolint:errcheck
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unhealthy status code %d", res.StatusCode)
+	}
+
+	return nil
+}
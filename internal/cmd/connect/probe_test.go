@@ -0,0 +1,43 @@
+package connect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestWaitHealthy(t *testing.T) {
+	c := qt.New(t)
+
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the dependency is only up on the third check
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	err := waitHealthy(context.Background(), srv.URL, 10*time.Millisecond, 10*time.Second)
+	c.Assert(err, qt.IsNil)
+	c.Assert(atomic.LoadInt32(&calls), qt.Equals, int32(3))
+}
+
+func TestWaitHealthy_Timeout(t *testing.T) {
+	c := qt.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := waitHealthy(context.Background(), srv.URL, 10*time.Millisecond, 50*time.Millisecond)
+	c.Assert(err, qt.ErrorMatches, "timed out waiting for .* to become healthy: unhealthy status code 500")
+}
@@ -0,0 +1,133 @@
+package connect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+)
+
+// tunnelFileName is the name of the file, in the project root, that has the
+// address of the running proxy.
+const tunnelFileName = ".pscale-tunnel.json"
+
+// tunnelFile writes the local address of the proxy to a file while the proxy
+// is running, so other tools can find the port. A nil *tunnelFile does
+// nothing.
+//
+// Every connect in a project writes the same file, so it has the address of
+// the proxy that was started last. A proxy only removes the file if it still
+// has its own address, so it doesn't remove the one of another proxy that is
+// still running.
+type tunnelFile struct {
+	path string
+
+	mu      sync.Mutex
+	content []byte
+	removed bool
+}
+
+func newTunnelFile(dir string) *tunnelFile {
+	return &tunnelFile{path: filepath.Join(dir, tunnelFileName)}
+}
+
+// tunnel is the content of the tunnel file.
+type tunnel struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Database string `json:"database"`
+	Branch   string `json:"branch"`
+}
+
+// write writes the address to the file. It doesn't write anything once the
+// file was removed, as the proxy is already shutting down then.
+func (t *tunnelFile) write(la *localAddress) error {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.removed {
+		return nil
+	}
+
+	out, err := json.MarshalIndent(&tunnel{
+		Host:     la.Host,
+		Port:     la.Port,
+		Database: la.Database,
+		Branch:   la.Branch,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	out = append(out, '\n')
+	if err := ioutil.WriteFile(t.path, out, 0644); err != nil {
+		return err
+	}
+
+	t.content = out
+	return nil
+}
+
+// remove removes the file if it was written and wasn't overwritten by another
+// proxy since.
+func (t *tunnelFile) remove() error {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.removed = true
+	if t.content == nil {
+		return nil
+	}
+
+	content := t.content
+	t.content = nil
+
+	current, err := ioutil.ReadFile(t.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(current, content) {
+		return nil
+	}
+
+	if err := os.Remove(t.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// gitIgnored reports whether Git ignores the file at path.
+func gitIgnored(path string) (bool, error) {
+	cmd := exec.Command("git", "check-ignore", "-q", filepath.Base(path))
+	cmd.Dir = filepath.Dir(path)
+
+	err := cmd.Run()
+	if err == nil {
+		return true, nil
+	}
+
+	// check-ignore exits with 1 if the file isn't ignored
+	var ee *exec.ExitError
+	if errors.As(err, &ee) && ee.ExitCode() == 1 {
+		return false, nil
+	}
+
+	return false, err
+}
@@ -0,0 +1,36 @@
+package connect
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestTunnelFile_SeveralProxies(t *testing.T) {
+	c := qt.New(t)
+
+	dir := c.TempDir()
+	path := filepath.Join(dir, tunnelFileName)
+
+	first, second := newTunnelFile(dir), newTunnelFile(dir)
+	c.Assert(first.write(&localAddress{Host: "127.0.0.1", Port: 3306, Database: "mydb", Branch: "main"}), qt.IsNil)
+	c.Assert(second.write(&localAddress{Host: "127.0.0.1", Port: 3307, Database: "mydb", Branch: "dev"}), qt.IsNil)
+
+	// the file has the address of the second proxy, which is still running
+	c.Assert(first.remove(), qt.IsNil)
+	out, err := ioutil.ReadFile(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(out), qt.JSONEquals, map[string]interface{}{
+		"host":     "127.0.0.1",
+		"port":     3307,
+		"database": "mydb",
+		"branch":   "dev",
+	})
+
+	c.Assert(second.remove(), qt.IsNil)
+	_, err = os.Stat(path)
+	c.Assert(os.IsNotExist(err), qt.IsTrue)
+}
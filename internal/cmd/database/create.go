@@ -59,8 +59,10 @@ func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			end()
 
-			if ch.Printer.Format() == printer.Human {
-				ch.Printer.Printf("Database %s was successfully created.\n", printer.BoldBlue(database.Name))
+			if ch.Printer.IsHuman() {
+				ch.Printer.Printf("Database %s was successfully created.\n\nView it in your web browser: %s\n",
+					printer.BoldBlue(database.Name),
+					printer.Bold(fmt.Sprintf("%s/%s/%s", cmdutil.ApplicationURL, ch.Config.Organization, database.Name)))
 				return nil
 			}
 
@@ -1,9 +1,11 @@
 package database
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
@@ -18,13 +20,18 @@ import (
 // DeleteCmd is the Cobra command for deleting a database for an authenticated
 // user.
 func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
-	var force bool
+	var flags struct {
+		force       bool
+		wait        bool
+		waitTimeout time.Duration
+	}
 
 	cmd := &cobra.Command{
-		Use:     "delete <database>",
-		Short:   "Delete a database instance",
-		Args:    cmdutil.RequiredArgs("database"),
-		Aliases: []string{"rm"},
+		Use:               "delete <database>",
+		Short:             "Delete a database instance",
+		Args:              cmdutil.RequiredArgs("database"),
+		ValidArgsFunction: cmdutil.DatabaseCompletionFunc(ch),
+		Aliases:           []string{"rm"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			name := args[0]
@@ -34,9 +41,9 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 				return err
 			}
 
-			if !force {
-				if ch.Printer.Format() != printer.Human {
-					return fmt.Errorf("cannot delete database with the output format %q (run with -force to override)", ch.Printer.Format())
+			if !flags.force {
+				if format := ch.Printer.Format(); !format.IsHuman() {
+					return fmt.Errorf("cannot delete database with the output format %q (run with -force to override)", format.String())
 				}
 
 				if !printer.IsTTY {
@@ -95,9 +102,19 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 				}
 			}
 
+			if flags.wait {
+				err := waitDatabaseDeleted(ctx, client, &planetscale.GetDatabaseRequest{
+					Organization: ch.Config.Organization,
+					Database:     name,
+				}, flags.waitTimeout)
+				if err != nil {
+					return err
+				}
+			}
+
 			end()
 
-			if ch.Printer.Format() == printer.Human {
+			if ch.Printer.IsHuman() {
 				ch.Printer.Printf("Database %s was successfully deleted.\n", printer.BoldBlue(name))
 				return nil
 			}
@@ -111,6 +128,43 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVar(&force, "force", false, "Delete a databse without confirmation")
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Delete a databse without confirmation")
+	cmd.Flags().BoolVar(&flags.wait, "wait", false, "Wait until the database is gone before returning")
+	cmd.Flags().DurationVar(&flags.waitTimeout, "wait-timeout", 5*time.Minute, "Stop waiting for the database to be deleted after this long")
 	return cmd
 }
+
+// deletePollInterval is how often a database is checked while waiting for it
+// to be deleted.
+var deletePollInterval = time.Second
+
+// waitDatabaseDeleted polls the database until the API doesn't return it
+// anymore.
+func waitDatabaseDeleted(ctx context.Context, client *planetscale.Client, getReq *planetscale.GetDatabaseRequest, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(deletePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for database %s to be deleted", printer.BoldBlue(getReq.Database))
+		case <-ticker.C:
+			_, err := client.Databases.Get(ctx, getReq)
+			if err == nil {
+				continue
+			}
+
+			if cmdutil.ErrCode(err) == planetscale.ErrNotFound {
+				return nil
+			}
+
+			if ctx.Err() != nil {
+				return fmt.Errorf("timed out waiting for database %s to be deleted", printer.BoldBlue(getReq.Database))
+			}
+			return cmdutil.HandleError(err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/config"
@@ -59,3 +60,119 @@ func TestDatabase_DeleteCmd(t *testing.T) {
 	c.Assert(svc.DeleteFnInvoked, qt.IsTrue)
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestDatabase_DeleteCmd_Wait(t *testing.T) {
+	c := qt.New(t)
+
+	defer func(d time.Duration) { deletePollInterval = d }(deletePollInterval)
+	deletePollInterval = time.Millisecond
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+
+	var gets int
+	svc := &mock.DatabaseService{
+		DeleteFn: func(ctx context.Context, req *ps.DeleteDatabaseRequest) error {
+			return nil
+		},
+		GetFn: func(ctx context.Context, req *ps.GetDatabaseRequest) (*ps.Database, error) {
+			c.Assert(req.Organization, qt.Equals, org)
+			c.Assert(req.Database, qt.Equals, db)
+
+			gets++
+			if gets < 3 {
+				return &ps.Database{Name: db}, nil
+			}
+			return nil, &ps.Error{Code: ps.ErrNotFound}
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	cmd := DeleteCmd(ch)
+	cmd.SetArgs([]string{db, "--force", "--wait"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(gets, qt.Equals, 3)
+	c.Assert(buf.String(), qt.JSONEquals, map[string]string{
+		"result":   "database deleted",
+		"database": db,
+	})
+}
+
+func TestDatabase_DeleteCmd_NotFound(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	svc := &mock.DatabaseService{
+		DeleteFn: func(ctx context.Context, req *ps.DeleteDatabaseRequest) error {
+			return &ps.Error{Code: ps.ErrNotFound}
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	cmd := DeleteCmd(ch)
+	cmd.SetArgs([]string{"mydb", "--force"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "database .*mydb.* does not exist in organization .*planetscale.*")
+	c.Assert(buf.String(), qt.Equals, "")
+}
+
+func TestDatabase_DeleteCmd_RequiresForce(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+
+	svc := &mock.DatabaseService{}
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	cmd := DeleteCmd(ch)
+	cmd.SetArgs([]string{"mydb"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, `cannot delete database with the output format "json" \(run with -force to override\)`)
+	c.Assert(svc.DeleteFnInvoked, qt.IsFalse)
+}
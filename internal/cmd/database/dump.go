@@ -31,10 +31,11 @@ type dumpFlags struct {
 func DumpCmd(ch *cmdutil.Helper) *cobra.Command {
 	f := &dumpFlags{}
 	cmd := &cobra.Command{
-		Use:   "dump <database> <branch> [options]",
-		Short: "Backup and dump your database",
-		Args:  cmdutil.RequiredArgs("database", "branch"),
-		RunE:  func(cmd *cobra.Command, args []string) error { return dump(ch, cmd, f, args) },
+		Use:               "dump <database> <branch> [options]",
+		Short:             "Backup and dump your database",
+		Args:              cmdutil.RequiredArgs("database", "branch"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		RunE:              func(cmd *cobra.Command, args []string) error { return dump(ch, cmd, f, args) },
 	}
 
 	cmd.PersistentFlags().StringVar(&f.localAddr, "local-addr",
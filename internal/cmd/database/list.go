@@ -1,7 +1,13 @@
 package database
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
@@ -14,10 +20,44 @@ import (
 
 // ListCmd is the command for listing all databases for an authenticated user.
 func ListCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		countByRegion   bool
+		createdToday    bool
+		createdThisWeek bool
+		jsonMap         bool
+		githubEnv       bool
+		exportCSVFile   string
+		csvAppend       bool
+		page            cmdutil.Pagination
+	}
+
 	cmd := &cobra.Command{
 		Use:     "list",
 		Short:   "List databases",
 		Aliases: []string{"ls"},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if flags.createdToday && flags.createdThisWeek {
+				return errors.New("--created-today and --created-this-week can't be used together")
+			}
+			if flags.csvAppend && flags.exportCSVFile == "" {
+				return errors.New("--csv-append can only be used with --export-csv-file")
+			}
+			if flags.exportCSVFile != "" && (flags.jsonMap || flags.countByRegion || flags.githubEnv) {
+				return errors.New("--export-csv-file can't be used with --output-json-map, --output-count-by-region or --output-github-env")
+			}
+			if flags.githubEnv && (flags.jsonMap || flags.countByRegion) {
+				return errors.New("--output-github-env can't be used with --output-json-map or --output-count-by-region")
+			}
+			if flags.jsonMap {
+				if format := ch.Printer.Format(); format != printer.JSON {
+					return fmt.Errorf("--output-json-map can't be used with the output format %q, use --format json", format.String())
+				}
+				if flags.countByRegion {
+					return errors.New("--output-json-map and --output-count-by-region can't be used together")
+				}
+			}
+			return flags.page.Validate(cmd, ch.Printer.IsHuman())
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			web, err := cmd.Flags().GetBool("web")
@@ -55,17 +95,214 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			end()
 
-			if len(databases) == 0 && ch.Printer.Format() == printer.Human {
+			if len(databases) == 0 && ch.Printer.IsHuman() {
 				ch.Printer.Println("No databases have been created yet.")
 				return nil
 			}
 
-			return ch.Printer.PrintResource(toDatabases(databases))
+			if flags.createdToday || flags.createdThisWeek {
+				since, period := startOfDay(time.Now()), "today"
+				if flags.createdThisWeek {
+					since, period = startOfWeek(time.Now()), "this week"
+				}
+
+				databases = createdSince(databases, since)
+				if len(databases) == 0 && ch.Printer.IsHuman() {
+					ch.Printer.Printf("No databases have been created %s.\n", period)
+					return nil
+				}
+			}
+
+			if flags.countByRegion {
+				return ch.Printer.PrintResource(countByRegion(databases))
+			}
+
+			// the map is for looking up databases by name, so it has all
+			// of them rather than a single page
+			if flags.jsonMap {
+				return ch.Printer.PrintResource(databasesByName(databases))
+			}
+
+			// later steps of the job need every database, not a page
+			if flags.githubEnv {
+				return outputGitHubEnv(ch, cmd.OutOrStdout(), databases)
+			}
+
+			first, last := flags.page.Bounds(len(databases))
+			if flags.exportCSVFile != "" {
+				// an export has all databases, unless a page is asked for
+				exported := databases
+				if cmd.Flags().Changed("page") || cmd.Flags().Changed("limit") {
+					exported = databases[first:last]
+				}
+				return exportCSV(ch, flags.exportCSVFile, flags.csvAppend, toDatabases(exported), time.Now())
+			}
+
+			if err := ch.Printer.PrintResource(toDatabases(databases[first:last])); err != nil {
+				return err
+			}
+
+			ch.Printer.Print(flags.page.Footer(len(databases)))
+			return nil
 		},
 		TraverseChildren: true,
 	}
 
 	cmd.Flags().BoolP("web", "w", false, "Open in your web browser")
+	cmd.Flags().BoolVar(&flags.countByRegion, "output-count-by-region", false,
+		"Print the number of databases in each region instead of the databases")
+	cmd.Flags().BoolVar(&flags.createdToday, "created-today", false,
+		"Only list databases created today, in the local time zone")
+	cmd.Flags().BoolVar(&flags.createdThisWeek, "created-this-week", false,
+		"Only list databases created since Monday, in the local time zone")
+	cmd.Flags().BoolVar(&flags.jsonMap, "output-json-map", false,
+		"Print a JSON object keyed by database name instead of a list. Requires --format json")
+	cmd.Flags().BoolVar(&flags.githubEnv, "output-github-env", false,
+		"Append PSCALE_DATABASES with the comma-separated database names to the $GITHUB_ENV file of GitHub Actions, "+
+			"or print the command to do so if $GITHUB_ENV isn't set")
+	cmd.Flags().StringVar(&flags.exportCSVFile, "export-csv-file", "",
+		"Write all databases as CSV to this file instead of printing them, or a single page with --page or --limit. "+
+			"{date} in the path is replaced with today's date, such as 2006-01-02")
+	cmd.Flags().BoolVar(&flags.csvAppend, "csv-append", false,
+		"Append to the file of --export-csv-file instead of overwriting it. The header row is only written to empty files")
+	flags.page.AddFlags(cmd)
 
 	return cmd
 }
+
+// exportCSV writes the databases as CSV to the file at path, with {date}
+// replaced by the date of now. If appendFile is true, the databases are
+// appended to the file, and the header row is left out unless the file is
+// empty.
+func exportCSV(ch *cmdutil.Helper, path string, appendFile bool, databases Databases, now time.Time) error {
+	path = strings.ReplaceAll(path, "{date}", now.Format("2006-01-02"))
+
+	mode := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendFile {
+		mode = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	f, err := os.OpenFile(path, mode, 0644)
+	if err != nil {
+		return fmt.Errorf("can't open CSV file: %s", err)
+	}
+
+	noHeader := false
+	if appendFile {
+		fi, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("can't open CSV file: %s", err)
+		}
+		noHeader = fi.Size() > 0
+	}
+
+	if err := ch.Printer.PrintCSV(f, databases, noHeader); err != nil {
+		f.Close()
+		return fmt.Errorf("can't write CSV file: %s", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("can't write CSV file: %s", err)
+	}
+
+	ch.Printer.Printf("Exported %d databases to %s.\n", len(databases), printer.BoldBlue(path))
+	return nil
+}
+
+// outputGitHubEnv sets PSCALE_DATABASES to the names of the databases for the
+// next steps of a GitHub Actions job, by appending it to the file $GITHUB_ENV
+// points to. Outside of GitHub Actions, the command to append it is printed
+// to out instead.
+func outputGitHubEnv(ch *cmdutil.Helper, out io.Writer, databases []*planetscale.Database) error {
+	names := make([]string, 0, len(databases))
+	for _, db := range databases {
+		names = append(names, db.Name)
+	}
+	line := "PSCALE_DATABASES=" + strings.Join(names, ",")
+
+	path := os.Getenv("GITHUB_ENV")
+	if path == "" {
+		fmt.Fprintf(out, "echo %q >> $GITHUB_ENV\n", line)
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("can't open $GITHUB_ENV: %s", err)
+	}
+
+	if _, err := fmt.Fprintln(f, line); err != nil {
+		f.Close()
+		return fmt.Errorf("can't write to $GITHUB_ENV: %s", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("can't write to $GITHUB_ENV: %s", err)
+	}
+
+	ch.Printer.Printf("Added PSCALE_DATABASES with %d databases to %s.\n", len(names), printer.BoldBlue(path))
+	return nil
+}
+
+// databasesByName returns the databases keyed by their name.
+func databasesByName(databases []*planetscale.Database) map[string]*Database {
+	out := make(map[string]*Database, len(databases))
+	for _, db := range databases {
+		out[db.Name] = toDatabase(db)
+	}
+
+	return out
+}
+
+// createdSince returns the databases that were created at or after since.
+func createdSince(databases []*planetscale.Database, since time.Time) []*planetscale.Database {
+	out := make([]*planetscale.Database, 0, len(databases))
+	for _, db := range databases {
+		if !db.CreatedAt.Before(since) {
+			out = append(out, db)
+		}
+	}
+
+	return out
+}
+
+// startOfDay returns the midnight of the day of t, in its time zone.
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the midnight of the Monday of the week of t, in its
+// time zone.
+func startOfWeek(t time.Time) time.Time {
+	// Sunday is the last day of the week rather than the first.
+	days := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -days)
+}
+
+// regionCount is the number of databases in a single region.
+type regionCount struct {
+	Region string `header:"region,n/a" json:"region"`
+	Count  int    `header:"count" json:"count"`
+}
+
+// countByRegion groups the given databases by their region slug, sorted by
+// region.
+func countByRegion(databases []*planetscale.Database) []*regionCount {
+	counts := make(map[string]int)
+	for _, db := range databases {
+		counts[db.Region.Slug]++
+	}
+
+	out := make([]*regionCount, 0, len(counts))
+	for region, count := range counts {
+		out = append(out, &regionCount{Region: region, Count: count})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Region < out[j].Region
+	})
+
+	return out
+}
@@ -3,7 +3,14 @@ package database
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/config"
@@ -12,6 +19,7 @@ import (
 	ps "github.com/planetscale/planetscale-go/planetscale"
 
 	qt "github.com/frankban/quicktest"
+	"gopkg.in/yaml.v2"
 )
 
 func TestDatabase_ListCmd(t *testing.T) {
@@ -56,3 +64,596 @@ func TestDatabase_ListCmd(t *testing.T) {
 	c.Assert(svc.ListFnInvoked, qt.IsTrue)
 	c.Assert(buf.String(), qt.JSONEquals, dbs)
 }
+
+func TestDatabase_ListCmd_NoDatabasesTableBox(t *testing.T) {
+	c := qt.New(t)
+
+	var out, res bytes.Buffer
+	format := printer.TableBox
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&out)
+	p.SetResourceOutput(&res)
+
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			return nil, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(out.String(), qt.Matches, `(?s).*No databases have been created yet.\n`)
+	c.Assert(res.String(), qt.Equals, "")
+}
+
+func TestDatabase_ListCmd_JSONMap(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	dbs := []*ps.Database{
+		{Name: "foo", Notes: "first"},
+		{Name: "bar"},
+	}
+
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			return dbs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	// the map isn't cut into pages
+	cmd.SetArgs([]string{"--output-json-map", "--limit", "1"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.JSONEquals, map[string]*ps.Database{
+		"foo": dbs[0],
+		"bar": dbs[1],
+	})
+}
+
+func TestDatabase_ListCmd_JSONMapRequiresJSON(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+
+	svc := &mock.DatabaseService{}
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"--output-json-map"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, `--output-json-map can't be used with the output format "human", use --format json`)
+	c.Assert(svc.ListFnInvoked, qt.IsFalse)
+}
+
+func TestDatabase_ListCmd_GitHubEnv(t *testing.T) {
+	c := qt.New(t)
+
+	path := filepath.Join(c.TempDir(), "github_env")
+	err := ioutil.WriteFile(path, []byte("FOO=bar\n"), 0644)
+	c.Assert(err, qt.IsNil)
+
+	old, ok := os.LookupEnv("GITHUB_ENV")
+	os.Setenv("GITHUB_ENV", path)
+	c.Cleanup(func() {
+		if ok {
+			os.Setenv("GITHUB_ENV", old)
+		} else {
+			os.Unsetenv("GITHUB_ENV")
+		}
+	})
+
+	var buf bytes.Buffer
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&buf)
+
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			return []*ps.Database{{Name: "foo"}, {Name: "bar"}}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"--output-github-env"})
+	err = cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	out, err := ioutil.ReadFile(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(out), qt.Equals, "FOO=bar\nPSCALE_DATABASES=foo,bar\n")
+	c.Assert(buf.String(), qt.Contains, "Added PSCALE_DATABASES with 2 databases")
+}
+
+func TestDatabase_ListCmd_GitHubEnvAllPages(t *testing.T) {
+	c := qt.New(t)
+
+	old, ok := os.LookupEnv("GITHUB_ENV")
+	os.Unsetenv("GITHUB_ENV")
+	c.Cleanup(func() {
+		if ok {
+			os.Setenv("GITHUB_ENV", old)
+		}
+	})
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(ioutil.Discard)
+
+	// more than the default --limit of a page
+	dbs := namedDatabases(60)
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			return dbs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	var buf bytes.Buffer
+	cmd := ListCmd(ch)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--output-github-env"})
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	names := make([]string, 0, len(dbs))
+	for _, db := range dbs {
+		names = append(names, db.Name)
+	}
+	c.Assert(buf.String(), qt.Equals, `echo "PSCALE_DATABASES=`+strings.Join(names, ",")+`" >> $GITHUB_ENV`+"\n")
+}
+
+// namedDatabases returns n databases named db-1 to db-n.
+func namedDatabases(n int) []*ps.Database {
+	dbs := make([]*ps.Database, 0, n)
+	for i := 1; i <= n; i++ {
+		dbs = append(dbs, &ps.Database{Name: fmt.Sprintf("db-%d", i)})
+	}
+
+	return dbs
+}
+
+func TestDatabase_ListCmd_GitHubEnvUnset(t *testing.T) {
+	c := qt.New(t)
+
+	old, ok := os.LookupEnv("GITHUB_ENV")
+	os.Unsetenv("GITHUB_ENV")
+	c.Cleanup(func() {
+		if ok {
+			os.Setenv("GITHUB_ENV", old)
+		}
+	})
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(ioutil.Discard)
+
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			return []*ps.Database{{Name: "foo"}, {Name: "bar"}}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	var buf bytes.Buffer
+	cmd := ListCmd(ch)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"--output-github-env"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.Equals, `echo "PSCALE_DATABASES=foo,bar" >> $GITHUB_ENV`+"\n")
+}
+
+func TestDatabase_ListCmd_ExportCSVFile(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(ioutil.Discard)
+
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			return []*ps.Database{{Name: "foo", Notes: "first, and only"}}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	dir := c.TempDir()
+	for i := 0; i < 2; i++ {
+		cmd := ListCmd(ch)
+		cmd.SetArgs([]string{"--export-csv-file", filepath.Join(dir, "databases-{date}.csv"), "--csv-append"})
+		err := cmd.Execute()
+		c.Assert(err, qt.IsNil)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.csv"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(files, qt.HasLen, 1)
+	c.Assert(filepath.Base(files[0]), qt.Matches, `databases-\d{4}-\d{2}-\d{2}\.csv`)
+
+	out, err := ioutil.ReadFile(files[0])
+	c.Assert(err, qt.IsNil)
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	c.Assert(lines, qt.HasLen, 3)
+	c.Assert(lines[0], qt.Equals, "Name,CreatedAt,UpdatedAt,Notes")
+	c.Assert(lines[1], qt.Matches, `foo,.*,"first, and only"`)
+	c.Assert(lines[2], qt.Equals, lines[1])
+}
+
+func TestDatabase_ListCmd_ExportCSVFileAllPages(t *testing.T) {
+	c := qt.New(t)
+
+	var out bytes.Buffer
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&out)
+
+	// more than the default --limit of a page
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			return namedDatabases(60), nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	dir := c.TempDir()
+	csvLines := func(name string, args ...string) []string {
+		path := filepath.Join(dir, name)
+		out.Reset()
+
+		cmd := ListCmd(ch)
+		cmd.SetArgs(append([]string{"--export-csv-file", path}, args...))
+		err := cmd.Execute()
+		c.Assert(err, qt.IsNil)
+
+		buf, err := ioutil.ReadFile(path)
+		c.Assert(err, qt.IsNil)
+		return strings.Split(strings.TrimSpace(string(buf)), "\n")
+	}
+
+	// the header row and every database
+	c.Assert(csvLines("all.csv"), qt.HasLen, 61)
+	c.Assert(out.String(), qt.Contains, "Exported 60 databases")
+
+	lines := csvLines("page.csv", "--page", "2", "--limit", "20")
+	c.Assert(lines, qt.HasLen, 21)
+	c.Assert(lines[1], qt.Matches, "db-21,.*")
+	c.Assert(out.String(), qt.Contains, "Exported 20 databases")
+}
+
+func TestDatabase_ListCmd_NoHeaders(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.Human
+	noHeaders := true
+	p := printer.NewPrinter(&format)
+	p.SetNoHeaders(&noHeaders)
+	p.SetHumanOutput(ioutil.Discard)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+
+	dbs := []*ps.Database{
+		{Name: "foo"},
+		{Name: "bar"},
+	}
+
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			c.Assert(req.Organization, qt.Equals, org)
+			return dbs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+
+		},
+	}
+
+	cmd := ListCmd(ch)
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListFnInvoked, qt.IsTrue)
+
+	out := buf.String()
+	c.Assert(strings.Contains(out, "NAME"), qt.IsFalse)
+	c.Assert(strings.Contains(out, "foo"), qt.IsTrue)
+	c.Assert(strings.Contains(out, "bar"), qt.IsTrue)
+}
+
+func TestDatabase_ListCmd_CountByRegion(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+
+	dbs := []*ps.Database{
+		{Name: "foo", Region: ps.Region{Slug: "us-east"}},
+		{Name: "bar", Region: ps.Region{Slug: "eu-west"}},
+		{Name: "baz", Region: ps.Region{Slug: "us-east"}},
+	}
+
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			c.Assert(req.Organization, qt.Equals, org)
+			return dbs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"--output-count-by-region"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListFnInvoked, qt.IsTrue)
+
+	res := []map[string]interface{}{
+		{"region": "eu-west", "count": 1},
+		{"region": "us-east", "count": 2},
+	}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestDatabase_ListCmd_YAML(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.YAML
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	dbs := []*ps.Database{
+		{Name: "foo", Notes: "first"},
+		{Name: "bar"},
+	}
+
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			return dbs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	var out []map[string]interface{}
+	err = yaml.Unmarshal(buf.Bytes(), &out)
+	c.Assert(err, qt.IsNil)
+	c.Assert(out, qt.HasLen, 2)
+	c.Assert(out[0]["name"], qt.Equals, "foo")
+	c.Assert(out[0]["notes"], qt.Equals, "first")
+	c.Assert(out[1]["name"], qt.Equals, "bar")
+}
+
+func TestDatabase_ListCmd_CreatedToday(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	now := time.Now()
+	today := &ps.Database{Name: "today", CreatedAt: now}
+	dbs := []*ps.Database{
+		today,
+		{Name: "older", CreatedAt: now.Add(-48 * time.Hour)},
+	}
+
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			return dbs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"--created-today"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.JSONEquals, []*ps.Database{today})
+}
+
+func TestStartOfWeek(t *testing.T) {
+	c := qt.New(t)
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	monday := time.Date(2021, 6, 14, 0, 0, 0, 0, loc)
+
+	c.Assert(startOfWeek(time.Date(2021, 6, 16, 15, 4, 5, 0, loc)), qt.Equals, monday)
+	c.Assert(startOfWeek(time.Date(2021, 6, 20, 23, 59, 0, 0, loc)), qt.Equals, monday)
+	c.Assert(startOfWeek(monday), qt.Equals, monday)
+}
+
+func TestDatabase_ListJSONNotPaged(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	// more than the default --limit of a page, which only applies to the
+	// human output
+	dbs := namedDatabases(60)
+	svc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			return dbs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	var out []map[string]interface{}
+	c.Assert(json.Unmarshal(buf.Bytes(), &out), qt.IsNil)
+	c.Assert(out, qt.HasLen, 60)
+}
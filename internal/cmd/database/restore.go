@@ -25,10 +25,11 @@ type restoreFlags struct {
 func RestoreCmd(ch *cmdutil.Helper) *cobra.Command {
 	f := &restoreFlags{}
 	cmd := &cobra.Command{
-		Use:   "restore-dump <database> <branch> [options]",
-		Short: "Restore your database from a local dump directory",
-		Args:  cmdutil.RequiredArgs("database", "branch"),
-		RunE:  func(cmd *cobra.Command, args []string) error { return restore(ch, cmd, f, args) },
+		Use:               "restore-dump <database> <branch> [options]",
+		Short:             "Restore your database from a local dump directory",
+		Args:              cmdutil.RequiredArgs("database", "branch"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		RunE:              func(cmd *cobra.Command, args []string) error { return restore(ch, cmd, f, args) },
 	}
 
 	cmd.PersistentFlags().StringVar(&f.localAddr, "local-addr",
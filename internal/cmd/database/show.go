@@ -1,7 +1,11 @@
 package database
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
@@ -13,44 +17,16 @@ import (
 )
 
 func ShowCmd(ch *cmdutil.Helper) *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "show <database>",
-		Short: "Retrieve information about a database",
-		Args:  cmdutil.RequiredArgs("database"),
-		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-			if len(args) != 0 {
-				return nil, cobra.ShellCompDirectiveNoFileComp
-			}
-
-			client, err := ch.Client()
-			if err != nil {
-				return nil, cobra.ShellCompDirectiveNoFileComp
-			}
-
-			org := ch.Config.Organization // --org flag
-			if org == "" {
-				cfg, err := ch.ConfigFS.DefaultConfig()
-				if err != nil {
-					return nil, cobra.ShellCompDirectiveNoFileComp
-				}
-
-				org = cfg.Organization
-			}
-
-			databases, err := client.Databases.List(cmd.Context(), &planetscale.ListDatabasesRequest{
-				Organization: org,
-			})
-			if err != nil {
-				return nil, cobra.ShellCompDirectiveNoFileComp
-			}
-
-			candidates := make([]string, 0, len(databases))
-			for _, db := range databases {
-				candidates = append(candidates, db.Name)
-			}
+	var flags struct {
+		includeBranches bool
+		maxBranches     int
+	}
 
-			return candidates, cobra.ShellCompDirectiveNoFileComp
-		},
+	cmd := &cobra.Command{
+		Use:               "show <database>",
+		Short:             "Retrieve information about a database",
+		Args:              cmdutil.RequiredArgs("database"),
+		ValidArgsFunction: cmdutil.DatabaseCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			name := args[0]
@@ -69,6 +45,10 @@ func ShowCmd(ch *cmdutil.Helper) *cobra.Command {
 				return nil
 			}
 
+			if flags.includeBranches && flags.maxBranches <= 0 {
+				return errors.New("--max-branches must be greater than zero")
+			}
+
 			client, err := ch.Client()
 			if err != nil {
 				return err
@@ -90,13 +70,115 @@ func ShowCmd(ch *cmdutil.Helper) *cobra.Command {
 					return cmdutil.HandleError(err)
 				}
 			}
+
+			var branches []*planetscale.DatabaseBranch
+			if flags.includeBranches {
+				branches, err = client.DatabaseBranches.List(ctx, &planetscale.ListDatabaseBranchesRequest{
+					Organization: ch.Config.Organization,
+					Database:     name,
+				})
+				if err != nil {
+					return cmdutil.HandleError(err)
+				}
+			}
 			end()
 
+			total := len(branches)
+			if total > flags.maxBranches {
+				branches = branches[:flags.maxBranches]
+			}
+
+			if ch.Printer.IsHuman() {
+				ch.Printer.Print(databaseDetails(ch.Config.Organization, database))
+				if !flags.includeBranches {
+					return nil
+				}
+
+				if total == 0 {
+					ch.Printer.Println("\nNo branches exist in this database.")
+					return nil
+				}
+
+				if total > len(branches) {
+					ch.Printer.Printf("\n%s (showing %d of %d)\n", printer.Bold("Branches:"), len(branches), total)
+				} else {
+					ch.Printer.Printf("\n%s\n", printer.Bold("Branches:"))
+				}
+				return ch.Printer.PrintResource(toDatabaseBranches(branches))
+			}
+
+			if format := ch.Printer.Format(); flags.includeBranches && (format == printer.JSON || format == printer.YAML || format == printer.JSONLines) {
+				return ch.Printer.PrintResource(&databaseWithBranches{
+					Database: database,
+					Branches: branches,
+				})
+			}
+
 			return ch.Printer.PrintResource(toDatabase(database))
 		},
 	}
 
 	cmd.Flags().BoolP("web", "w", false, "Open in your web browser")
+	cmd.Flags().BoolVar(&flags.includeBranches, "include-branches", false,
+		"Also show the branches of the database. They're embedded under 'branches' in the JSON output")
+	cmd.Flags().IntVar(&flags.maxBranches, "max-branches", 10, "Maximum number of branches to show with --include-branches")
 
 	return cmd
 }
+
+// databaseWithBranches is the JSON output of a database with its branches
+// embedded.
+type databaseWithBranches struct {
+	*planetscale.Database
+	Branches []*planetscale.DatabaseBranch `json:"branches"`
+}
+
+// databaseBranch is the table row of a branch embedded in a database's
+// details.
+type databaseBranch struct {
+	Name       string `header:"name"`
+	Production bool   `header:"production"`
+	Ready      bool   `header:"ready"`
+	CreatedAt  int64  `header:"created_at,timestamp(ms|utc|human)"`
+}
+
+func toDatabaseBranches(branches []*planetscale.DatabaseBranch) []*databaseBranch {
+	out := make([]*databaseBranch, 0, len(branches))
+	for _, b := range branches {
+		out = append(out, &databaseBranch{
+			Name:       b.Name,
+			Production: b.Production,
+			Ready:      b.Ready,
+			CreatedAt:  printer.GetMilliseconds(b.CreatedAt),
+		})
+	}
+	return out
+}
+
+// databaseDetails returns the human readable details of a single database, one
+// field per line.
+func databaseDetails(org string, db *planetscale.Database) string {
+	timestamp := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.UTC().Format("2006-01-02 15:04:05 MST")
+	}
+
+	region := db.Region.Name
+	if region == "" {
+		region = db.Region.Slug
+	}
+
+	var buf strings.Builder
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Name:"), db.Name)
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Region:"), region)
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Notes:"), db.Notes)
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Created at:"), timestamp(db.CreatedAt))
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Updated at:"), timestamp(db.UpdatedAt))
+	fmt.Fprintf(w, "%s\t%s/%s/%s\n", printer.Bold("URL:"), cmdutil.ApplicationURL, org, db.Name)
+	w.Flush() // nolint:errcheck
+
+	return buf.String()
+}
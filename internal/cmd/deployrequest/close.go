@@ -1,22 +1,36 @@
 package deployrequest
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
 	"github.com/planetscale/planetscale-go/planetscale"
 
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/spf13/cobra"
 )
 
 // CloseCmd is the command for closing deploy requests.
 func CloseCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		force  bool
+		reason string
+	}
+
 	cmd := &cobra.Command{
 		Use:   "close <database> <number>",
 		Short: "Close a deploy request",
-		Args:  cmdutil.RequiredArgs("database", "number"),
+		Long: `Close a deploy request without deploying it.
+
+Deploy requests that are already closed or deployed can't be closed. Use
+--reason to add a comment explaining why the deploy request is closed.`,
+		Args:              cmdutil.RequiredArgs("database", "number"),
+		ValidArgsFunction: cmdutil.DatabaseCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
@@ -32,7 +46,7 @@ func CloseCmd(ch *cmdutil.Helper) *cobra.Command {
 				return fmt.Errorf("the argument <number> is invalid: %s", err)
 			}
 
-			dr, err := client.DeployRequests.CloseDeploy(ctx, &planetscale.CloseDeployRequestRequest{
+			dr, err := client.DeployRequests.Get(ctx, &planetscale.GetDeployRequestRequest{
 				Organization: ch.Config.Organization,
 				Database:     database,
 				Number:       n,
@@ -47,7 +61,76 @@ func CloseCmd(ch *cmdutil.Helper) *cobra.Command {
 				}
 			}
 
-			if ch.Printer.Format() == printer.Human {
+			// watchState reports a failed deployment even if the deploy
+			// request is closed, so its own state is checked for that.
+			switch {
+			case watchState(dr) == "complete":
+				return fmt.Errorf("deploy request %s/%s is already deployed and can't be closed",
+					printer.BoldBlue(database), printer.BoldBlue(number))
+			case dr.State == "closed":
+				return fmt.Errorf("deploy request %s/%s is already closed",
+					printer.BoldBlue(database), printer.BoldBlue(number))
+			}
+
+			if !flags.force {
+				if format := ch.Printer.Format(); !format.IsHuman() {
+					return fmt.Errorf("cannot close deploy request with the output format %q (run with --force to override)", format.String())
+				}
+
+				confirmationName := fmt.Sprintf("%s/%s", database, number)
+				if !printer.IsTTY {
+					return fmt.Errorf("cannot confirm closing deploy request %q (run with --force to override)", confirmationName)
+				}
+
+				prompt := &survey.Confirm{
+					Message: fmt.Sprintf("Close deploy request %s from %s into %s?",
+						printer.BoldBlue(confirmationName), printer.BoldBlue(dr.Branch), printer.BoldBlue(dr.IntoBranch)),
+				}
+
+				var confirmed bool
+				err = survey.AskOne(prompt, &confirmed)
+				if err != nil {
+					if err == terminal.InterruptErr {
+						os.Exit(0)
+					} else {
+						return err
+					}
+				}
+
+				if !confirmed {
+					return errors.New("skipping closing the deploy request")
+				}
+			}
+
+			if flags.reason != "" {
+				_, err := client.DeployRequests.CreateReview(ctx, &planetscale.ReviewDeployRequestRequest{
+					Organization: ch.Config.Organization,
+					Database:     database,
+					Number:       n,
+					ReviewAction: planetscale.ReviewComment,
+					CommentText:  flags.reason,
+				})
+				if err != nil {
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			dr, err = client.DeployRequests.CloseDeploy(ctx, &planetscale.CloseDeployRequestRequest{
+				Organization: ch.Config.Organization,
+				Database:     database,
+				Number:       n,
+			})
+			if err != nil {
+				switch cmdutil.ErrCode(err) {
+				case planetscale.ErrNotFound:
+					return fmt.Errorf("deploy request '%s/%s' does not exist in organization %s",
+						printer.BoldBlue(database), printer.BoldBlue(number), printer.BoldBlue(ch.Config.Organization))
+				default:
+					return cmdutil.HandleError(err)
+				}
+			}
+
+			if ch.Printer.IsHuman() {
 				ch.Printer.Printf("Deploy request %s/%s was successfully closed.\n",
 					printer.BoldBlue(database),
 					printer.BoldBlue(dr.Number))
@@ -58,5 +141,8 @@ func CloseCmd(ch *cmdutil.Helper) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Close the deploy request without confirmation")
+	cmd.Flags().StringVar(&flags.reason, "reason", "", "Comment on the deploy request with the reason for closing it")
+
 	return cmd
 }
@@ -28,6 +28,10 @@ func TestDeployRequest_CloseCmd(t *testing.T) {
 	var number uint64 = 10
 
 	svc := &mock.DeployRequestsService{
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			c.Assert(req.Number, qt.Equals, number)
+			return &ps.DeployRequest{Number: number, State: "open"}, nil
+		},
 		CloseFn: func(ctx context.Context, req *ps.CloseDeployRequestRequest) (*ps.DeployRequest, error) {
 			c.Assert(req.Number, qt.Equals, number)
 			c.Assert(req.Database, qt.Equals, db)
@@ -51,7 +55,7 @@ func TestDeployRequest_CloseCmd(t *testing.T) {
 	}
 
 	cmd := CloseCmd(ch)
-	cmd.SetArgs([]string{db, strconv.FormatUint(number, 10)})
+	cmd.SetArgs([]string{db, strconv.FormatUint(number, 10), "--force"})
 	err := cmd.Execute()
 
 	c.Assert(err, qt.IsNil)
@@ -60,3 +64,113 @@ func TestDeployRequest_CloseCmd(t *testing.T) {
 	res := &DeployRequest{Number: number}
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestDeployRequest_CloseCmd_Reason(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	svc := &mock.DeployRequestsService{
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			return &ps.DeployRequest{Number: 10, State: "open"}, nil
+		},
+		CreateReviewFn: func(ctx context.Context, req *ps.ReviewDeployRequestRequest) (*ps.DeployRequestReview, error) {
+			c.Assert(req.Number, qt.Equals, uint64(10))
+			c.Assert(req.ReviewAction, qt.Equals, ps.ReviewComment)
+			c.Assert(req.CommentText, qt.Equals, "opened by mistake")
+			return &ps.DeployRequestReview{}, nil
+		},
+		CloseFn: func(ctx context.Context, req *ps.CloseDeployRequestRequest) (*ps.DeployRequest, error) {
+			return &ps.DeployRequest{Number: 10, State: "closed"}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+		},
+	}
+
+	cmd := CloseCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "10", "--force", "--reason", "opened by mistake"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.CreateReviewFnInvoked, qt.IsTrue)
+	c.Assert(svc.CloseFnInvoked, qt.IsTrue)
+}
+
+func TestDeployRequest_CloseCmd_AlreadyClosed(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&bytes.Buffer{})
+
+	svc := &mock.DeployRequestsService{
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			return &ps.DeployRequest{Number: 10, State: "closed"}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+		},
+	}
+
+	cmd := CloseCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "10", "--force"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "deploy request planetscale/10 is already closed")
+	c.Assert(svc.CloseFnInvoked, qt.IsFalse)
+}
+
+func TestDeployRequest_CloseCmd_RequiresForce(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&bytes.Buffer{})
+
+	svc := &mock.DeployRequestsService{
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			return &ps.DeployRequest{Number: 10, State: "open"}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+		},
+	}
+
+	cmd := CloseCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "10"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, `cannot close deploy request with the output format "json" \(run with --force to override\)`)
+	c.Assert(svc.CloseFnInvoked, qt.IsFalse)
+}
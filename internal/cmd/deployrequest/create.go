@@ -2,11 +2,14 @@ package deployrequest
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
 	"github.com/planetscale/planetscale-go/planetscale"
 
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/spf13/cobra"
 )
 
@@ -14,12 +17,14 @@ import (
 func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 	var flags struct {
 		deployTo string
+		notes    string
 	}
 
 	cmd := &cobra.Command{
-		Use:   "create <database> <branch> [flags]",
-		Short: "Create a deploy request from a branch",
-		Args:  cmdutil.RequiredArgs("database", "branch"),
+		Use:               "create <database> <branch> [flags]",
+		Short:             "Create a deploy request from a branch",
+		Args:              cmdutil.RequiredArgs("database", "branch"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
@@ -30,6 +35,23 @@ func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 				return err
 			}
 
+			// ask for notes only if they weren't passed explicitly, an empty
+			// --notes skips the prompt.
+			if !cmd.Flags().Changed("notes") && printer.IsTTY && ch.Printer.IsHuman() {
+				prompt := &survey.Input{
+					Message: "Notes for the deploy request (optional):",
+				}
+
+				err = survey.AskOne(prompt, &flags.notes)
+				if err != nil {
+					if err == terminal.InterruptErr {
+						os.Exit(0)
+					} else {
+						return err
+					}
+				}
+			}
+
 			end := ch.Printer.PrintProgress(fmt.Sprintf("Request deploying of %s branch in %s...", printer.BoldBlue(branch), printer.BoldBlue(database)))
 			defer end()
 
@@ -38,6 +60,7 @@ func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 				Database:     database,
 				Branch:       branch,
 				IntoBranch:   flags.deployTo,
+				Notes:        flags.notes,
 			})
 			if err != nil {
 				switch cmdutil.ErrCode(err) {
@@ -50,7 +73,7 @@ func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 			}
 			end()
 
-			if ch.Printer.Format() == printer.Human {
+			if ch.Printer.IsHuman() {
 				number := fmt.Sprintf("#%d", dr.Number)
 				ch.Printer.Printf("Deploy request %s successfully created.\n", printer.BoldBlue(number))
 				return nil
@@ -61,6 +84,7 @@ func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 	}
 
 	cmd.PersistentFlags().StringVar(&flags.deployTo, "deploy-to", "main", "Branch to deploy the branch. By default it's set to 'main'")
+	cmd.PersistentFlags().StringVar(&flags.notes, "notes", "", "Notes to attach to the deploy request. If omitted in an interactive shell, you'll be prompted for them")
 
 	return cmd
 }
@@ -1,8 +1,11 @@
 package deployrequest
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
@@ -13,10 +16,16 @@ import (
 
 // DeployCmd is the command for deploying deploy requests.
 func DeployCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		wait        bool
+		waitTimeout time.Duration
+	}
+
 	cmd := &cobra.Command{
-		Use:   "deploy <database> <number>",
-		Short: "Deploy a specific deploy request",
-		Args:  cmdutil.RequiredArgs("database", "number"),
+		Use:               "deploy <database> <number>",
+		Short:             "Deploy a specific deploy request",
+		Args:              cmdutil.RequiredArgs("database", "number"),
+		ValidArgsFunction: cmdutil.DatabaseCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
@@ -42,12 +51,62 @@ func DeployCmd(ch *cmdutil.Helper) *cobra.Command {
 				case planetscale.ErrNotFound:
 					return fmt.Errorf("deploy request '%s/%s' does not exist in organization %s",
 						printer.BoldBlue(database), printer.BoldBlue(number), printer.BoldBlue(ch.Config.Organization))
+				case planetscale.ErrInvalid, planetscale.ErrPermission:
+					// the API doesn't tell why a deploy request can't be
+					// deployed, so check whether it's still waiting for an
+					// approval.
+					if notApproved(ctx, client, ch.Config.Organization, database, n) {
+						return fmt.Errorf("deploy request '%s/%s' has not been approved yet, approve it with 'pscale deploy-request review %s %s --approve' first",
+							printer.BoldBlue(database), printer.BoldBlue(number), database, number)
+					}
+					return cmdutil.HandleError(err)
 				default:
 					return cmdutil.HandleError(err)
 				}
 			}
 
-			if ch.Printer.Format() == printer.Human {
+			if flags.wait {
+				end := ch.Printer.PrintProgress(fmt.Sprintf("Deploying %s from %s to %s...",
+					printer.BoldBlue(number), printer.BoldBlue(dr.Branch), printer.BoldBlue(dr.IntoBranch)))
+				defer end()
+
+				dr, err = waitDeployment(ctx, client, &planetscale.GetDeployRequestRequest{
+					Organization: ch.Config.Organization,
+					Database:     database,
+					Number:       n,
+				}, flags.waitTimeout)
+				if err != nil {
+					return err
+				}
+				end()
+
+				state := watchState(dr)
+				if !deploymentSucceeded(state) {
+					if !ch.Printer.IsHuman() {
+						if err := ch.Printer.PrintResource(toDeployRequest(dr)); err != nil {
+							return err
+						}
+					}
+					return fmt.Errorf("deploy request %s/%s finished with state %s",
+						printer.BoldBlue(database), printer.BoldBlue(number), printer.BoldRed(state))
+				}
+
+				if ch.Printer.IsHuman() && state == "no_changes" {
+					ch.Printer.Printf("Deploy request %s from %s to %s had no changes to deploy.\n",
+						dr.ID, dr.Branch, dr.IntoBranch)
+					return nil
+				}
+
+				if ch.Printer.IsHuman() {
+					ch.Printer.Printf("Successfully deployed %s from %s to %s.\n",
+						dr.ID, dr.Branch, dr.IntoBranch)
+					return nil
+				}
+
+				return ch.Printer.PrintResource(toDeployRequest(dr))
+			}
+
+			if ch.Printer.IsHuman() {
 				ch.Printer.Printf("Successfully queued %s from %s for deployment to %s.\n",
 					dr.ID, dr.Branch, dr.IntoBranch)
 				return nil
@@ -57,5 +116,65 @@ func DeployCmd(ch *cmdutil.Helper) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVar(&flags.wait, "wait", false, "Wait until the deployment finishes")
+	cmd.Flags().DurationVar(&flags.waitTimeout, "wait-timeout", 10*time.Minute, "Stop waiting for the deployment after this long")
+
 	return cmd
 }
+
+// deployPollInterval is how often a deploy request is checked while waiting
+// for its deployment to finish.
+var deployPollInterval = 2 * time.Second
+
+// waitDeployment polls the deploy request until its deployment has finished,
+// successfully or not, and returns it.
+func waitDeployment(ctx context.Context, client *planetscale.Client, getReq *planetscale.GetDeployRequestRequest, timeout time.Duration) (*planetscale.DeployRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(deployPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, waitError(ctx)
+		case <-ticker.C:
+			dr, err := client.DeployRequests.Get(ctx, getReq)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, waitError(ctx)
+				}
+				return nil, cmdutil.HandleError(err)
+			}
+
+			if deploymentFinished(watchState(dr)) {
+				return dr, nil
+			}
+		}
+	}
+}
+
+// waitError returns the error for a wait on the deployment that ended
+// because ctx is done.
+func waitError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.New("timed out waiting for the deployment to finish")
+	}
+	return ctx.Err()
+}
+
+// notApproved reports whether the deploy request exists and is waiting for
+// an approval.
+func notApproved(ctx context.Context, client *planetscale.Client, org, database string, number uint64) bool {
+	dr, err := client.DeployRequests.Get(ctx, &planetscale.GetDeployRequestRequest{
+		Organization: org,
+		Database:     database,
+		Number:       number,
+	})
+	if err != nil {
+		return false
+	}
+
+	return dr.State == "open" && !dr.Approved
+}
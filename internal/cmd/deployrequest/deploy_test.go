@@ -5,6 +5,7 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/config"
@@ -60,3 +61,151 @@ func TestDeployRequest_DeployCmd(t *testing.T) {
 	res := &DeployRequest{Number: number}
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestDeployRequest_DeployCmd_Wait(t *testing.T) {
+	c := qt.New(t)
+
+	defer func(d time.Duration) { deployPollInterval = d }(deployPollInterval)
+	deployPollInterval = time.Millisecond
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	var number uint64 = 10
+
+	var gets int
+	svc := &mock.DeployRequestsService{
+		DeployFn: func(ctx context.Context, req *ps.PerformDeployRequest) (*ps.DeployRequest, error) {
+			return &ps.DeployRequest{Number: number, State: "open", Deployment: &ps.Deployment{State: "queued"}}, nil
+		},
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			c.Assert(req.Number, qt.Equals, number)
+
+			gets++
+			if gets < 3 {
+				return &ps.DeployRequest{Number: number, State: "open", Deployment: &ps.Deployment{State: "in_progress"}}, nil
+			}
+			return &ps.DeployRequest{Number: number, State: "closed", Deployment: &ps.Deployment{State: "complete"}}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+		},
+	}
+
+	cmd := DeployCmd(ch)
+	cmd.SetArgs([]string{db, strconv.FormatUint(number, 10), "--wait"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(gets, qt.Equals, 3)
+
+	res := &DeployRequest{Number: number, State: "closed", Deployment: inlineDeployment{State: "complete"}}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestDeployRequest_DeployCmd_WaitFailed(t *testing.T) {
+	c := qt.New(t)
+
+	defer func(d time.Duration) { deployPollInterval = d }(deployPollInterval)
+	deployPollInterval = time.Millisecond
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	var number uint64 = 10
+
+	var gets int
+	svc := &mock.DeployRequestsService{
+		DeployFn: func(ctx context.Context, req *ps.PerformDeployRequest) (*ps.DeployRequest, error) {
+			return &ps.DeployRequest{Number: number, State: "open", Deployment: &ps.Deployment{State: "queued"}}, nil
+		},
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			gets++
+			if gets < 2 {
+				return &ps.DeployRequest{Number: number, State: "open", Deployment: &ps.Deployment{State: "in_progress"}}, nil
+			}
+			// a failed deployment leaves the deploy request open
+			return &ps.DeployRequest{Number: number, State: "open", Deployment: &ps.Deployment{State: "complete_error"}}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+		},
+	}
+
+	start := time.Now()
+	cmd := DeployCmd(ch)
+	cmd.SetArgs([]string{"planetscale", strconv.FormatUint(number, 10), "--wait"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "deploy request .* finished with state .*complete_error.*")
+	c.Assert(gets, qt.Equals, 2)
+	c.Assert(time.Since(start) < time.Second, qt.IsTrue)
+
+	res := &DeployRequest{Number: number, State: "open", Deployment: inlineDeployment{State: "complete_error"}}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestDeployRequest_DeployCmd_NotApproved(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	var number uint64 = 10
+
+	svc := &mock.DeployRequestsService{
+		DeployFn: func(ctx context.Context, req *ps.PerformDeployRequest) (*ps.DeployRequest, error) {
+			return nil, &ps.Error{Code: ps.ErrInvalid}
+		},
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			return &ps.DeployRequest{Number: number, State: "open"}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+		},
+	}
+
+	cmd := DeployCmd(ch)
+	cmd.SetArgs([]string{db, strconv.FormatUint(number, 10)})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "deploy request .* has not been approved yet.*")
+	c.Assert(svc.GetFnInvoked, qt.IsTrue)
+}
@@ -22,9 +22,10 @@ func DiffCmd(ch *cmdutil.Helper) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "diff <database> <number>",
-		Short: "Show the diff of a deploy request",
-		Args:  cmdutil.RequiredArgs("database", "number"),
+		Use:               "diff <database> <number>",
+		Short:             "Show the diff of a deploy request",
+		Args:              cmdutil.RequiredArgs("database", "number"),
+		ValidArgsFunction: cmdutil.DatabaseCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
@@ -60,30 +61,11 @@ func DiffCmd(ch *cmdutil.Helper) *cobra.Command {
 				}
 			}
 
-			if ch.Printer.Format() != printer.Human {
+			if !ch.Printer.IsHuman() {
 				return ch.Printer.PrintResource(diffs)
 			}
 
-			// human readable output
-			for _, df := range diffs {
-				ch.Printer.Println("--", printer.BoldBlue(df.Name), "--")
-				scanner := bufio.NewScanner(strings.NewReader(strings.TrimSpace(df.Raw)))
-				for scanner.Scan() {
-					txt := scanner.Text()
-					if strings.HasPrefix(txt, "+") {
-						ch.Printer.Println(color.New(color.FgGreen).Add(color.Bold).Sprint(txt)) //nolint: errcheck
-					} else if strings.HasPrefix(txt, "-") {
-						ch.Printer.Println(color.New(color.FgRed).Add(color.Bold).Sprint(txt)) //nolint: errcheck
-					} else {
-						ch.Printer.Println(txt)
-					}
-				}
-				if err := scanner.Err(); err != nil {
-					return fmt.Errorf("reading diff raw: %s", err)
-				}
-			}
-
-			return nil
+			return printDiffs(ch, diffs)
 		},
 	}
 
@@ -91,3 +73,27 @@ func DiffCmd(ch *cmdutil.Helper) *cobra.Command {
 
 	return cmd
 }
+
+// printDiffs prints the diffs in a human readable format, with added lines in
+// green and removed lines in red.
+func printDiffs(ch *cmdutil.Helper, diffs []*planetscale.Diff) error {
+	for _, df := range diffs {
+		ch.Printer.Println("--", printer.BoldBlue(df.Name), "--")
+		scanner := bufio.NewScanner(strings.NewReader(strings.TrimSpace(df.Raw)))
+		for scanner.Scan() {
+			txt := scanner.Text()
+			if strings.HasPrefix(txt, "+") {
+				ch.Printer.Println(color.New(color.FgGreen).Add(color.Bold).Sprint(txt)) //nolint: errcheck
+			} else if strings.HasPrefix(txt, "-") {
+				ch.Printer.Println(color.New(color.FgRed).Add(color.Bold).Sprint(txt)) //nolint: errcheck
+			} else {
+				ch.Printer.Println(txt)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("reading diff raw: %s", err)
+		}
+	}
+
+	return nil
+}
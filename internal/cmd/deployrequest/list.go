@@ -1,7 +1,9 @@
 package deployrequest
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
@@ -13,14 +15,45 @@ import (
 
 // ListCmd is the command for listing deploy requests.
 func ListCmd(ch *cmdutil.Helper) *cobra.Command {
+	var database string
+	var flags struct {
+		branch     string
+		intoBranch string
+		limit      int
+	}
+
 	cmd := &cobra.Command{
-		Use:     "list <database>",
-		Short:   "List all deploy requests for a database",
-		Aliases: []string{"ls"},
-		Args:    cmdutil.RequiredArgs("database"),
+		Use:   "list [database]",
+		Short: "List all deploy requests for a database",
+		Long: `List all deploy requests for a database. The database can be given as an
+argument, with the --database flag, or with the database setting of the
+pscale.yml configuration file.
+
+Use --branch and --into-branch to only list the deploy requests from or into a
+branch. Unlike --database, --branch is never read from the branch setting of
+pscale.yml, so the list isn't filtered unless you ask for it.
+
+Use --limit to only list the most recently created deploy requests, newest
+first.`,
+		Aliases:           []string{"ls"},
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: cmdutil.DatabaseCompletionFunc(ch),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				database = args[0]
+			}
+
+			if database == "" {
+				return errors.New("a database is required: pass it as an argument, with --database, or set 'database' in your pscale.yml")
+			}
+
+			if flags.limit < 0 {
+				return errors.New("--limit can't be negative")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
-			database := args[0]
 
 			web, err := cmd.Flags().GetBool("web")
 			if err != nil {
@@ -29,7 +62,7 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			if web {
 				ch.Printer.Println("🌐  Redirecting you to your deploy-requests list in your web browser.")
-				err := browser.OpenURL(fmt.Sprintf("%s/%s", cmdutil.ApplicationURL, ch.Config.Organization))
+				err := browser.OpenURL(fmt.Sprintf("%s/%s/%s/deploy-requests", cmdutil.ApplicationURL, ch.Config.Organization, database))
 				if err != nil {
 					return err
 				}
@@ -59,7 +92,15 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 			}
 			end()
 
-			if len(deployRequests) == 0 && ch.Printer.Format() == printer.Human {
+			if flags.branch != "" || flags.intoBranch != "" {
+				deployRequests = filterBranches(deployRequests, flags.branch, flags.intoBranch)
+			}
+
+			if flags.limit > 0 {
+				deployRequests = mostRecent(deployRequests, flags.limit)
+			}
+
+			if len(deployRequests) == 0 && ch.Printer.IsHuman() {
 				ch.Printer.Printf("No deploy requests exist for %s.\n", printer.BoldBlue(database))
 				return nil
 			}
@@ -70,6 +111,45 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 	}
 
 	cmd.Flags().BoolP("web", "w", false, "Open in your web browser")
+	cmd.Flags().StringVar(&database, "database", "", "The database to list deploy requests for")
+	cmd.Flags().StringVar(&flags.branch, "branch", "", "Only list deploy requests from this branch")
+	cmd.Flags().StringVar(&flags.intoBranch, "into-branch", "", "Only list deploy requests into this branch")
+	cmdutil.SkipConfig(cmd, "branch")
+	cmd.Flags().IntVar(&flags.limit, "limit", 0, "Only list this many of the most recent deploy requests. 0 lists all of them")
 
 	return cmd
 }
+
+// filterBranches returns the deploy requests from the given branch and into
+// the given branch. An empty branch matches any branch.
+func filterBranches(drs []*planetscale.DeployRequest, branch, intoBranch string) []*planetscale.DeployRequest {
+	out := make([]*planetscale.DeployRequest, 0, len(drs))
+	for _, dr := range drs {
+		if branch != "" && dr.Branch != branch {
+			continue
+		}
+		if intoBranch != "" && dr.IntoBranch != intoBranch {
+			continue
+		}
+		out = append(out, dr)
+	}
+
+	return out
+}
+
+// mostRecent returns the n most recently created deploy requests, newest
+// first.
+func mostRecent(drs []*planetscale.DeployRequest, n int) []*planetscale.DeployRequest {
+	out := make([]*planetscale.DeployRequest, len(drs))
+	copy(out, drs)
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+
+	if n < len(out) {
+		out = out[:n]
+	}
+
+	return out
+}
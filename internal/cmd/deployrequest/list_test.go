@@ -3,7 +3,10 @@ package deployrequest
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/config"
@@ -63,3 +66,197 @@ func TestDeployRequest_ListCmd(t *testing.T) {
 	}
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestDeployRequest_ListCmd_DatabaseFlag(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+
+	svc := &mock.DeployRequestsService{
+		ListFn: func(ctx context.Context, req *ps.ListDeployRequestsRequest) ([]*ps.DeployRequest, error) {
+			c.Assert(req.Organization, qt.Equals, org)
+			c.Assert(req.Database, qt.Equals, db)
+
+			return []*ps.DeployRequest{{Number: 1}}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"--database", db})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListFnInvoked, qt.IsTrue)
+	c.Assert(buf.String(), qt.JSONEquals, []*DeployRequest{{Number: 1}})
+}
+
+func TestDeployRequest_ListCmd_NoDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+
+	svc := &mock.DeployRequestsService{}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{})
+	cmd.SetOut(ioutil.Discard)
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "a database is required.*")
+	c.Assert(svc.ListFnInvoked, qt.IsFalse)
+}
+
+func TestDeployRequest_ListCmd_BranchFilters(t *testing.T) {
+	c := qt.New(t)
+
+	drs := []*ps.DeployRequest{
+		{Number: 1, Branch: "feature", IntoBranch: "main"},
+		{Number: 2, Branch: "feature", IntoBranch: "staging"},
+		{Number: 3, Branch: "fix", IntoBranch: "main"},
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []*DeployRequest
+	}{
+		{
+			name: "branch",
+			args: []string{"--branch", "feature"},
+			want: []*DeployRequest{
+				{Number: 1, Branch: "feature", IntoBranch: "main"},
+				{Number: 2, Branch: "feature", IntoBranch: "staging"},
+			},
+		},
+		{
+			name: "into branch",
+			args: []string{"--into-branch", "main"},
+			want: []*DeployRequest{
+				{Number: 1, Branch: "feature", IntoBranch: "main"},
+				{Number: 3, Branch: "fix", IntoBranch: "main"},
+			},
+		},
+		{
+			name: "both",
+			args: []string{"--branch", "feature", "--into-branch", "staging"},
+			want: []*DeployRequest{
+				{Number: 2, Branch: "feature", IntoBranch: "staging"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			var buf bytes.Buffer
+			format := printer.JSON
+			p := printer.NewPrinter(&format)
+			p.SetResourceOutput(&buf)
+
+			svc := &mock.DeployRequestsService{
+				ListFn: func(ctx context.Context, req *ps.ListDeployRequestsRequest) ([]*ps.DeployRequest, error) {
+					return drs, nil
+				},
+			}
+
+			ch := &cmdutil.Helper{
+				Printer: p,
+				Config: &config.Config{
+					Organization: "planetscale",
+				},
+				Client: func() (*ps.Client, error) {
+					return &ps.Client{
+						DeployRequests: svc,
+					}, nil
+				},
+			}
+
+			cmd := ListCmd(ch)
+			cmd.SetArgs(append([]string{"planetscale"}, tt.args...))
+			err := cmd.Execute()
+
+			c.Assert(err, qt.IsNil)
+			c.Assert(buf.String(), qt.JSONEquals, tt.want)
+		})
+	}
+}
+
+func TestDeployRequest_ListCmd_Limit(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	now := time.Now()
+	drs := []*ps.DeployRequest{
+		{Number: 1, CreatedAt: now.Add(-3 * time.Hour)},
+		{Number: 3, CreatedAt: now.Add(-1 * time.Hour)},
+		{Number: 2, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	svc := &mock.DeployRequestsService{
+		ListFn: func(ctx context.Context, req *ps.ListDeployRequestsRequest) ([]*ps.DeployRequest, error) {
+			return drs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "--limit", "2"})
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	var out []struct {
+		Number uint64 `json:"number"`
+	}
+	err = json.Unmarshal(buf.Bytes(), &out)
+	c.Assert(err, qt.IsNil)
+	c.Assert(out, qt.HasLen, 2)
+	c.Assert(out[0].Number, qt.Equals, uint64(3))
+	c.Assert(out[1].Number, qt.Equals, uint64(2))
+}
@@ -21,9 +21,10 @@ func ReviewCmd(ch *cmdutil.Helper) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "review <database> <number>",
-		Short: "Review a deploy request (approve, comment, etc...)",
-		Args:  cmdutil.RequiredArgs("database", "number"),
+		Use:               "review <database> <number>",
+		Short:             "Review a deploy request (approve, comment, etc...)",
+		Args:              cmdutil.RequiredArgs("database", "number"),
+		ValidArgsFunction: cmdutil.DatabaseCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !flags.approve && flags.comment == "" {
 				return errors.New("neither --approve nor --comment is set")
@@ -65,12 +66,18 @@ func ReviewCmd(ch *cmdutil.Helper) *cobra.Command {
 				}
 			}
 
-			if ch.Printer.Format() != printer.Human {
+			if !ch.Printer.IsHuman() {
 				return ch.Printer.PrintResource(drr)
 			}
 
 			switch action {
 			case planetscale.ReviewApprove:
+				if flags.comment != "" {
+					ch.Printer.Printf("Deploy request %s/%s is approved with the comment:\n\n%s\n",
+						printer.BoldBlue(database), printer.BoldBlue(number), flags.comment)
+					return nil
+				}
+
 				ch.Printer.Printf("Deploy request %s/%s is approved.\n",
 					printer.BoldBlue(database), printer.BoldBlue(number))
 			case planetscale.ReviewComment:
@@ -83,7 +90,7 @@ func ReviewCmd(ch *cmdutil.Helper) *cobra.Command {
 	}
 
 	cmd.PersistentFlags().BoolVar(&flags.approve, "approve", false, "Approve a deploy request")
-	cmd.PersistentFlags().StringVar(&flags.comment, "comment", "", "Comment on a deploy request")
+	cmd.PersistentFlags().StringVar(&flags.comment, "comment", "", "Comment on a deploy request. If used with --approve, the comment is attached to the approval")
 
 	return cmd
 }
@@ -67,3 +67,53 @@ func TestDeployRequest_ReviewCmd(t *testing.T) {
 
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestDeployRequest_ReviewCmd_ApproveWithComment(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	var number uint64 = 10
+	comment := "looks good to me"
+
+	res := &ps.DeployRequestReview{
+		Body:  comment,
+		State: "approved",
+	}
+
+	svc := &mock.DeployRequestsService{
+		CreateReviewFn: func(ctx context.Context, req *ps.ReviewDeployRequestRequest) (*ps.DeployRequestReview, error) {
+			c.Assert(req.ReviewAction, qt.Equals, ps.ReviewApprove)
+			c.Assert(req.CommentText, qt.Equals, comment)
+
+			return res, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+
+		},
+	}
+
+	cmd := ReviewCmd(ch)
+	cmd.SetArgs([]string{db, strconv.FormatUint(number, 10), "--approve", "--comment", comment})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.CreateReviewFnInvoked, qt.IsTrue)
+
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
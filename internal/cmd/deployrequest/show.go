@@ -3,6 +3,9 @@ package deployrequest
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/pkg/browser"
 	"github.com/planetscale/cli/internal/cmdutil"
@@ -15,13 +18,15 @@ import (
 // ShowCmd is the command to show a deploy request.
 func ShowCmd(ch *cmdutil.Helper) *cobra.Command {
 	var flags struct {
-		web bool
+		web         bool
+		includeDiff bool
 	}
 
 	cmd := &cobra.Command{
-		Use:   "show <database> <number>",
-		Short: "Show a specific deploy request",
-		Args:  cmdutil.RequiredArgs("database", "number"),
+		Use:               "show <database> <number>",
+		Short:             "Show a specific deploy request",
+		Args:              cmdutil.RequiredArgs("database", "number"),
+		ValidArgsFunction: cmdutil.DatabaseCompletionFunc(ch),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
@@ -57,11 +62,109 @@ func ShowCmd(ch *cmdutil.Helper) *cobra.Command {
 				}
 			}
 
-			return ch.Printer.PrintResource(toDeployRequest(dr))
+			if !flags.includeDiff {
+				if ch.Printer.IsHuman() {
+					ch.Printer.Print(deployRequestDetails(ch.Config.Organization, database, dr))
+					return nil
+				}
+				return ch.Printer.PrintResource(toDeployRequest(dr))
+			}
+
+			diffs, err := client.DeployRequests.Diff(ctx, &planetscale.DiffRequest{
+				Organization: ch.Config.Organization,
+				Database:     database,
+				Number:       n,
+			})
+			if err != nil {
+				return cmdutil.HandleError(err)
+			}
+
+			switch format := ch.Printer.Format(); {
+			case format.IsHuman():
+				ch.Printer.Print(deployRequestDetails(ch.Config.Organization, database, dr))
+				ch.Printer.Println()
+
+				if len(diffs) == 0 {
+					ch.Printer.Println("This deploy request has no schema changes.")
+					return nil
+				}
+				return printDiffs(ch, diffs)
+			case format == printer.JSON, format == printer.YAML, format == printer.JSONLines:
+				return ch.Printer.PrintResource(&deployRequestWithDiff{
+					DeployRequest: toDeployRequest(dr),
+					SchemaDiff:    diffs,
+				})
+			default:
+				// the diff doesn't fit into the rows of a CSV output.
+				return ch.Printer.PrintResource(toDeployRequest(dr))
+			}
 		},
 	}
 
 	cmd.PersistentFlags().BoolVar(&flags.web, "web", false, "Open in your web browser")
+	cmd.Flags().BoolVar(&flags.includeDiff, "include-diff", false,
+		"Also show the schema diff of the deploy request. It's embedded under 'schema_diff' in the JSON output")
 
 	return cmd
 }
+
+// deployRequestWithDiff is the JSON output of a deploy request with its schema
+// diff embedded.
+type deployRequestWithDiff struct {
+	*DeployRequest
+	SchemaDiff []*planetscale.Diff `json:"schema_diff"`
+}
+
+// deployRequestDetails returns the human readable details of a deploy
+// request and its deployment.
+func deployRequestDetails(org, database string, dr *planetscale.DeployRequest) string {
+	timestamp := func(t *time.Time) string {
+		if t == nil || t.IsZero() {
+			return "-"
+		}
+		return t.UTC().Format("2006-01-02 15:04:05 MST")
+	}
+
+	value := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+
+	var buf strings.Builder
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\t%d\n", printer.Bold("Number:"), dr.Number)
+	fmt.Fprintf(w, "%s\t%s → %s\n", printer.Bold("Branch:"), dr.Branch, dr.IntoBranch)
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("State:"), dr.State)
+	fmt.Fprintf(w, "%s\t%t\n", printer.Bold("Approved:"), dr.Approved)
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Notes:"), value(dr.Notes))
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Created at:"), timestamp(&dr.CreatedAt))
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Updated at:"), timestamp(&dr.UpdatedAt))
+	fmt.Fprintf(w, "%s\t%s\n", printer.Bold("Closed at:"), timestamp(dr.ClosedAt))
+	fmt.Fprintf(w, "%s\t%s/%s/%s/deploy-requests/%d\n", printer.Bold("URL:"), cmdutil.ApplicationURL, org, database, dr.Number)
+	w.Flush() // nolint:errcheck
+
+	d := dr.Deployment
+	if d == nil {
+		return buf.String()
+	}
+
+	fmt.Fprintf(&buf, "\n%s\n", printer.Bold("Deployment:"))
+	w = tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  %s\t%s\n", printer.Bold("State:"), value(d.State))
+	fmt.Fprintf(w, "  %s\t%t\n", printer.Bold("Deployable:"), d.Deployable)
+	fmt.Fprintf(w, "  %s\t%s\n", printer.Bold("Queued at:"), timestamp(d.QueuedAt))
+	fmt.Fprintf(w, "  %s\t%s\n", printer.Bold("Started at:"), timestamp(d.StartedAt))
+	fmt.Fprintf(w, "  %s\t%s\n", printer.Bold("Finished at:"), timestamp(d.FinishedAt))
+	w.Flush() // nolint:errcheck
+
+	if len(d.LintErrors) > 0 {
+		fmt.Fprintf(&buf, "\n%s\n", printer.Bold("Lint errors:"))
+		for _, le := range d.LintErrors {
+			fmt.Fprintf(&buf, "  - %s: %s\n", le.Table, le.ErrorDescription)
+		}
+	}
+
+	return buf.String()
+}
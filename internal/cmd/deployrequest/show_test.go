@@ -60,3 +60,142 @@ func TestDeployRequest_ShowCmd(t *testing.T) {
 	res := &DeployRequest{Number: number}
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestDeployRequest_ShowCmd_IncludeDiff(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	var number uint64 = 10
+
+	diffs := []*ps.Diff{{Name: "users", Raw: "+CREATE TABLE users (id int)"}}
+
+	svc := &mock.DeployRequestsService{
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			return &ps.DeployRequest{Number: number}, nil
+		},
+		DiffFn: func(ctx context.Context, req *ps.DiffRequest) ([]*ps.Diff, error) {
+			c.Assert(req.Organization, qt.Equals, org)
+			c.Assert(req.Database, qt.Equals, db)
+			c.Assert(req.Number, qt.Equals, number)
+
+			return diffs, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+		},
+	}
+
+	cmd := ShowCmd(ch)
+	cmd.SetArgs([]string{db, strconv.FormatUint(number, 10), "--include-diff"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.DiffFnInvoked, qt.IsTrue)
+
+	res := &deployRequestWithDiff{
+		DeployRequest: &DeployRequest{Number: number},
+		SchemaDiff:    diffs,
+	}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestDeployRequest_ShowCmd_Human(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&buf)
+
+	svc := &mock.DeployRequestsService{
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			return &ps.DeployRequest{
+				Number:     10,
+				Branch:     "feature",
+				IntoBranch: "main",
+				State:      "open",
+				Notes:      "add users table",
+				Deployment: &ps.Deployment{
+					State: "pending",
+					LintErrors: []*ps.DeploymentLintError{
+						{Table: "users", ErrorDescription: "table has no primary key"},
+					},
+				},
+			}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+		},
+	}
+
+	cmd := ShowCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "10"})
+	err := cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	out := buf.String()
+	c.Assert(out, qt.Contains, "feature → main")
+	c.Assert(out, qt.Contains, "add users table")
+	c.Assert(out, qt.Contains, "/planetscale/planetscale/deploy-requests/10")
+	c.Assert(out, qt.Contains, "Deployment:")
+	c.Assert(out, qt.Contains, "pending")
+	c.Assert(out, qt.Contains, "users: table has no primary key")
+}
+
+func TestDeployRequest_ShowCmd_NotFound(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	svc := &mock.DeployRequestsService{
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			return nil, &ps.Error{Code: ps.ErrNotFound}
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+		},
+	}
+
+	cmd := ShowCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "42"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "deploy request 'planetscale/42' does not exist in organization planetscale")
+	c.Assert(buf.String(), qt.Equals, "")
+}
@@ -0,0 +1,159 @@
+package deployrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/printer"
+	"github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/spf13/cobra"
+)
+
+// WatchCmd is the command to watch a deploy request until its deployment
+// finishes.
+func WatchCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		pollInterval time.Duration
+		timeout      time.Duration
+	}
+
+	cmd := &cobra.Command{
+		Use:               "watch <database> <number>",
+		Short:             "Watch a deploy request and print its state until the deployment finishes",
+		Args:              cmdutil.RequiredArgs("database", "number"),
+		ValidArgsFunction: cmdutil.DatabaseCompletionFunc(ch),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			database := args[0]
+			number := args[1]
+
+			if flags.pollInterval <= 0 {
+				return errors.New("--poll-interval must be greater than zero")
+			}
+
+			client, err := ch.Client()
+			if err != nil {
+				return err
+			}
+
+			n, err := strconv.ParseUint(number, 10, 64)
+			if err != nil {
+				return fmt.Errorf("the argument <number> is invalid: %s", err)
+			}
+
+			if flags.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, flags.timeout)
+				defer cancel()
+			}
+
+			getReq := &planetscale.GetDeployRequestRequest{
+				Organization: ch.Config.Organization,
+				Database:     database,
+				Number:       n,
+			}
+
+			ticker := time.NewTicker(flags.pollInterval)
+			defer ticker.Stop()
+
+			var last string
+			for {
+				dr, err := client.DeployRequests.Get(ctx, getReq)
+				if err != nil {
+					if ctx.Err() == context.DeadlineExceeded {
+						return fmt.Errorf("timed out watching deploy request %s/%s", printer.BoldBlue(database), printer.BoldBlue(number))
+					}
+
+					switch cmdutil.ErrCode(err) {
+					case planetscale.ErrNotFound:
+						return fmt.Errorf("deploy request '%s/%s' does not exist in organization %s",
+							printer.BoldBlue(database), printer.BoldBlue(number), printer.BoldBlue(ch.Config.Organization))
+					default:
+						return cmdutil.HandleError(err)
+					}
+				}
+
+				state := watchState(dr)
+				if state != last {
+					ch.Printer.Printf("%s Deploy request %s/%s is %s\n",
+						time.Now().Format("15:04:05"), printer.BoldBlue(database), printer.BoldBlue(number), printer.Bold(state))
+					last = state
+				}
+
+				if deploymentFinished(state) {
+					if !ch.Printer.IsHuman() {
+						if err := ch.Printer.PrintResource(toDeployRequest(dr)); err != nil {
+							return err
+						}
+					}
+
+					if deploymentSucceeded(state) {
+						return nil
+					}
+					return fmt.Errorf("deploy request %s/%s finished with state %s",
+						printer.BoldBlue(database), printer.BoldBlue(number), printer.BoldRed(state))
+				}
+
+				select {
+				case <-ctx.Done():
+					if ctx.Err() == context.DeadlineExceeded {
+						return fmt.Errorf("timed out watching deploy request %s/%s", printer.BoldBlue(database), printer.BoldBlue(number))
+					}
+					return ctx.Err()
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&flags.pollInterval, "poll-interval", 5*time.Second, "How often to check the deploy request state")
+	cmd.Flags().DurationVar(&flags.timeout, "timeout", 0, "Stop watching after this long. Zero waits until the deployment finishes")
+
+	return cmd
+}
+
+// watchState returns the state to report for the given deploy request. The
+// state of a finished deployment is used even if the deploy request has been
+// closed since, so that a completed or failed deployment isn't reported as
+// closed. Otherwise the deployment state is used while the deploy request is
+// open.
+func watchState(dr *planetscale.DeployRequest) string {
+	if dr.Deployment != nil && deploymentFinished(dr.Deployment.State) {
+		return dr.Deployment.State
+	}
+
+	if dr.State == "closed" {
+		return "closed"
+	}
+
+	if dr.Deployment != nil && dr.Deployment.State != "" {
+		return dr.Deployment.State
+	}
+
+	return dr.State
+}
+
+// deploymentFinished reports whether the state returned by watchState is
+// final, whether the deployment succeeded or not. Every complete* state is
+// final, such as complete_error and complete_cancel.
+func deploymentFinished(state string) bool {
+	switch state {
+	case "cancelled", "no_changes", "error", "closed":
+		return true
+	}
+
+	return strings.HasPrefix(state, "complete")
+}
+
+// deploymentSucceeded reports whether the state returned by watchState is a
+// successful end of the deployment. A deployment without changes has
+// nothing left to do, so it counts as a success.
+func deploymentSucceeded(state string) bool {
+	return state == "complete" || state == "no_changes"
+}
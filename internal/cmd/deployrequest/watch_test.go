@@ -0,0 +1,212 @@
+package deployrequest
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"strconv"
+	"testing"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/config"
+	"github.com/planetscale/cli/internal/mock"
+	"github.com/planetscale/cli/internal/printer"
+
+	qt "github.com/frankban/quicktest"
+	ps "github.com/planetscale/planetscale-go/planetscale"
+)
+
+func TestDeployRequest_WatchCmd(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	var number uint64 = 10
+
+	states := []string{"queued", "in_progress", "complete"}
+	calls := 0
+
+	svc := &mock.DeployRequestsService{
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			c.Assert(req.Organization, qt.Equals, org)
+			c.Assert(req.Database, qt.Equals, db)
+			c.Assert(req.Number, qt.Equals, number)
+
+			state := states[calls]
+			calls++
+
+			return &ps.DeployRequest{
+				Number:     number,
+				State:      "open",
+				Deployment: &ps.Deployment{State: state},
+			}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+
+		},
+	}
+
+	cmd := WatchCmd(ch)
+	cmd.SetArgs([]string{db, strconv.FormatUint(number, 10), "--poll-interval", "1ms"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(calls, qt.Equals, 3)
+
+	res := &DeployRequest{
+		Number:     number,
+		State:      "open",
+		Deployment: inlineDeployment{State: "complete"},
+	}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
+
+func TestDeployRequest_WatchCmd_Closed(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	db := "planetscale"
+	var number uint64 = 10
+
+	svc := &mock.DeployRequestsService{
+		GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			return &ps.DeployRequest{Number: number, State: "closed"}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+
+		},
+	}
+
+	cmd := WatchCmd(ch)
+	cmd.SetArgs([]string{db, strconv.FormatUint(number, 10), "--poll-interval", "1ms"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "deploy request .* finished with state .*closed.*")
+	c.Assert(svc.GetFnInvoked, qt.IsTrue)
+}
+
+func TestDeployRequest_WatchCmd_FinalStates(t *testing.T) {
+	c := qt.New(t)
+
+	var tests = []struct {
+		state string
+		fails bool
+	}{
+		{state: "complete"},
+		{state: "no_changes"},
+		{state: "complete_error", fails: true},
+		{state: "complete_cancel", fails: true},
+		{state: "cancelled", fails: true},
+		{state: "error", fails: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.state, func(t *testing.T) {
+			format := printer.Human
+			p := printer.NewPrinter(&format)
+			p.SetHumanOutput(ioutil.Discard)
+
+			var number uint64 = 10
+			svc := &mock.DeployRequestsService{
+				GetFn: func(ctx context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+					return &ps.DeployRequest{
+						Number:     number,
+						State:      "open",
+						Deployment: &ps.Deployment{State: tt.state},
+					}, nil
+				},
+			}
+
+			ch := &cmdutil.Helper{
+				Printer: p,
+				Config: &config.Config{
+					Organization: "planetscale",
+				},
+				Client: func() (*ps.Client, error) {
+					return &ps.Client{
+						DeployRequests: svc,
+					}, nil
+				},
+			}
+
+			// the deploy request stays open, so only the deployment state
+			// can end the watch before the test times out.
+			cmd := WatchCmd(ch)
+			cmd.SetArgs([]string{"planetscale", strconv.FormatUint(number, 10), "--poll-interval", "1h"})
+			err := cmd.Execute()
+
+			if tt.fails {
+				c.Assert(err, qt.ErrorMatches, "deploy request .* finished with state .*"+tt.state+".*")
+			} else {
+				c.Assert(err, qt.IsNil)
+			}
+		})
+	}
+}
+
+func TestDeployRequest_WatchCmd_Canceled(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(ioutil.Discard)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	svc := &mock.DeployRequestsService{
+		GetFn: func(_ context.Context, req *ps.GetDeployRequestRequest) (*ps.DeployRequest, error) {
+			cancel()
+			return &ps.DeployRequest{
+				Number:     10,
+				State:      "open",
+				Deployment: &ps.Deployment{State: "in_progress"},
+			}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				DeployRequests: svc,
+			}, nil
+		},
+	}
+
+	cmd := WatchCmd(ch)
+	cmd.SetArgs([]string{"planetscale", "10", "--poll-interval", "1h"})
+	err := cmd.ExecuteContext(ctx)
+	c.Assert(err, qt.ErrorIs, context.Canceled)
+}
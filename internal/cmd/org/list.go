@@ -1,13 +1,26 @@
 package org
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
+	ps "github.com/planetscale/planetscale-go/planetscale"
 
 	"github.com/spf13/cobra"
 )
 
 func ListCmd(ch *cmdutil.Helper) *cobra.Command {
+	var flags struct {
+		activeSince       string
+		withDatabaseCount bool
+		concurrency       int
+	}
+
 	cmd := &cobra.Command{
 		Use:     "list",
 		Short:   "List the currently active organizations",
@@ -15,6 +28,20 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
+
+			if flags.concurrency < 1 {
+				return errors.New("--concurrency must be at least 1")
+			}
+
+			var since time.Time
+			if flags.activeSince != "" {
+				t, err := time.Parse(time.RFC3339, flags.activeSince)
+				if err != nil {
+					return fmt.Errorf("--active-since must be an RFC3339 date, such as 2021-06-01T00:00:00Z: %s", err)
+				}
+				since = t
+			}
+
 			client, err := ch.Client()
 			if err != nil {
 				return err
@@ -25,14 +52,118 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 				return cmdutil.HandleError(err)
 			}
 
-			if len(orgs) == 0 && ch.Printer.Format() == printer.Human {
+			if !since.IsZero() {
+				orgs = activeSince(orgs, since)
+			}
+
+			if len(orgs) == 0 && ch.Printer.IsHuman() {
 				ch.Printer.Printf("No organizations exist\n")
 				return nil
 			}
 
+			if flags.withDatabaseCount {
+				end := ch.Printer.PrintProgress("Counting databases...")
+				defer end()
+
+				counted, err := withDatabaseCounts(ctx, client, orgs, flags.concurrency)
+				if err != nil {
+					return err
+				}
+				end()
+
+				return ch.Printer.PrintResource(counted)
+			}
+
 			return ch.Printer.PrintResource(toOrgs(orgs))
 		},
 	}
 
+	cmd.Flags().StringVar(&flags.activeSince, "active-since", "",
+		"Only list organizations active since the given RFC3339 date. An organization is active when it was last modified, as visible through the API")
+	cmd.Flags().BoolVar(&flags.withDatabaseCount, "with-database-count", false,
+		"Show the number of databases in every organization")
+	cmd.Flags().IntVar(&flags.concurrency, "concurrency", 4,
+		"How many organizations to count the databases of at the same time, with --with-database-count")
+
+	// organizations aren't cached locally yet, so the API is always called
+	// and this flag has no effect. It's here so scripts can rely on it once a
+	// cache is added.
+	cmd.Flags().Bool("refresh-cache", false, "Fetch organizations from the API, ignoring any locally cached list")
+
 	return cmd
 }
+
+// activeSince returns the organizations that were updated at or after since.
+func activeSince(orgs []*ps.Organization, since time.Time) []*ps.Organization {
+	out := make([]*ps.Organization, 0, len(orgs))
+	for _, org := range orgs {
+		if !org.UpdatedAt.Before(since) {
+			out = append(out, org)
+		}
+	}
+
+	return out
+}
+
+// orgDatabaseCount is an organization along with its number of databases.
+type orgDatabaseCount struct {
+	Name          string `header:"name" json:"name"`
+	DatabaseCount int    `header:"database count" json:"database_count"`
+	CreatedAt     int64  `header:"created_at,timestamp(ms|utc|human)" json:"created_at"`
+	UpdatedAt     int64  `header:"updated_at,timestamp(ms|utc|human)" json:"updated_at"`
+}
+
+// withDatabaseCounts lists the databases of every organization, with at most
+// concurrency requests at a time, and returns the organizations along with
+// their number of databases.
+func withDatabaseCounts(ctx context.Context, client *ps.Client, orgs []*ps.Organization, concurrency int) ([]*orgDatabaseCount, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	out := make([]*orgDatabaseCount, len(orgs))
+	sem := make(chan struct{}, concurrency)
+
+	// only the first error is returned, as it cancels the other requests
+	var (
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	var wg sync.WaitGroup
+	for i, org := range orgs {
+		wg.Add(1)
+		go func(i int, org *ps.Organization) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			databases, err := client.Databases.List(ctx, &ps.ListDatabasesRequest{
+				Organization: org.Name,
+			})
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("listing databases of organization %s: %w", printer.BoldBlue(org.Name), cmdutil.HandleError(err))
+				}
+				mu.Unlock()
+				cancel()
+				return
+			}
+
+			out[i] = &orgDatabaseCount{
+				Name:          org.Name,
+				DatabaseCount: len(databases),
+				CreatedAt:     printer.GetMilliseconds(org.CreatedAt),
+				UpdatedAt:     printer.GetMilliseconds(org.UpdatedAt),
+			}
+		}(i, org)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return out, nil
+}
@@ -3,7 +3,9 @@ package org
 import (
 	"bytes"
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/config"
@@ -57,3 +59,131 @@ func TestOrganization_ListCmd(t *testing.T) {
 	}
 	c.Assert(buf.String(), qt.JSONEquals, orgs)
 }
+
+func TestOrganization_ListCmd_ActiveSince(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	org := "planetscale"
+	updated := time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	svc := &mock.OrganizationsService{
+		ListFn: func(ctx context.Context) ([]*ps.Organization, error) {
+			return []*ps.Organization{
+				{Name: "foo", UpdatedAt: updated},
+				{Name: "bar", UpdatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+			}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Organizations: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"--active-since", "2021-06-01T00:00:00Z"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListFnInvoked, qt.IsTrue)
+
+	orgs := []*organization{
+		{Name: "foo", UpdatedAt: printer.GetMilliseconds(updated)},
+	}
+	c.Assert(buf.String(), qt.JSONEquals, orgs)
+}
+
+// concurrentDatabaseService can be listed from several goroutines, unlike
+// mock.DatabaseService which records that it was invoked.
+type concurrentDatabaseService struct {
+	*mock.DatabaseService
+	list func(context.Context, *ps.ListDatabasesRequest) ([]*ps.Database, error)
+}
+
+func (d *concurrentDatabaseService) List(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+	return d.list(ctx, req)
+}
+
+func TestOrganization_ListCmd_WithDatabaseCount(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	svc := &mock.OrganizationsService{
+		ListFn: func(ctx context.Context) ([]*ps.Organization, error) {
+			return []*ps.Organization{
+				{Name: "foo"},
+				{Name: "bar"},
+				{Name: "baz"},
+			}, nil
+		},
+	}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	dbSvc := &concurrentDatabaseService{
+		list: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+
+			switch req.Organization {
+			case "foo":
+				return []*ps.Database{{Name: "a"}, {Name: "b"}}, nil
+			case "bar":
+				return []*ps.Database{{Name: "c"}}, nil
+			default:
+				return nil, nil
+			}
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config:  &config.Config{},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Organizations: svc,
+				Databases:     dbSvc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	cmd.SetArgs([]string{"--with-database-count", "--concurrency", "2"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(maxRunning <= 2, qt.IsTrue)
+
+	res := []map[string]interface{}{
+		{"name": "foo", "database_count": 2, "created_at": 0, "updated_at": 0},
+		{"name": "bar", "database_count": 1, "created_at": 0, "updated_at": 0},
+		{"name": "baz", "database_count": 0, "created_at": 0, "updated_at": 0},
+	}
+	c.Assert(buf.String(), qt.JSONEquals, res)
+}
@@ -47,7 +47,7 @@ func ShowCmd(ch *cmdutil.Helper) *cobra.Command {
 				return errors.New("config file exists, but organization is not set")
 			}
 
-			if ch.Printer.Format() == printer.Human {
+			if ch.Printer.IsHuman() {
 				ch.Printer.Printf("%s (from file: %s)\n", printer.Bold(cfg.Organization), configPath)
 				return nil
 			}
@@ -1,8 +1,10 @@
 package org
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/config"
@@ -18,6 +20,7 @@ import (
 func SwitchCmd(ch *cmdutil.Helper) *cobra.Command {
 	var flags struct {
 		filepath string
+		project  bool
 	}
 	cmd := &cobra.Command{
 		Use:   "switch <organization>",
@@ -47,6 +50,10 @@ func SwitchCmd(ch *cmdutil.Helper) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
+			if flags.project && flags.filepath != "" {
+				return errors.New("--project and --save-config can't be used together")
+			}
+
 			organization := ""
 
 			client, err := ch.Client()
@@ -119,6 +126,14 @@ func SwitchCmd(ch *cmdutil.Helper) *cobra.Command {
 				filePath = flags.filepath
 			}
 
+			if flags.project {
+				rootDir, err := config.ProjectConfigDir()
+				if err != nil {
+					return fmt.Errorf("--project can only be used inside a Git repository or a directory with a %s file: %s", config.ProjectConfigFile(), err)
+				}
+				filePath = path.Join(rootDir, config.ProjectConfigFile())
+			}
+
 			// fallback to the default global configuration path if nothing is
 			// set.
 			if filePath == "" {
@@ -159,6 +174,8 @@ func SwitchCmd(ch *cmdutil.Helper) *cobra.Command {
 
 	cmd.PersistentFlags().StringVar(&flags.filepath, "save-config", "",
 		"Path to store the organization. By default the configuration is deducted automatically based on where pscale is executed.")
+	cmd.PersistentFlags().BoolVar(&flags.project, "project", false,
+		"Store the organization in the project configuration (.pscale.yml): the closest one above the current directory, or one at the root of the current Git repository, creating it if needed.")
 
 	return cmd
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 
@@ -57,3 +58,54 @@ func TestOrganization_SwitchCmd(t *testing.T) {
 	c.Assert(string(out), qt.Equals, fmt.Sprintf("org: %s\n", organization))
 	c.Assert(buf.String(), qt.Contains, "Successfully switched to organization")
 }
+
+func TestOrganization_SwitchCmd_Project(t *testing.T) {
+	c := qt.New(t)
+
+	if _, err := exec.LookPath("git"); err != nil {
+		c.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	out, err := exec.Command("git", "init", repo).CombinedOutput()
+	c.Assert(err, qt.IsNil, qt.Commentf("git init: %s", out))
+
+	wd, err := os.Getwd()
+	c.Assert(err, qt.IsNil)
+	c.Assert(os.Chdir(repo), qt.IsNil)
+	defer os.Chdir(wd) // nolint:errcheck
+
+	var buf bytes.Buffer
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&buf)
+
+	organization := "planetscale"
+
+	svc := &mock.OrganizationsService{
+		GetFn: func(ctx context.Context, req *ps.GetOrganizationRequest) (*ps.Organization, error) {
+			return &ps.Organization{Name: organization}, nil
+		},
+	}
+	ch := &cmdutil.Helper{
+		Printer:  p,
+		ConfigFS: config.NewConfigFS(testutil.MemFS{}),
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Organizations: svc,
+			}, nil
+		},
+	}
+
+	cmd := SwitchCmd(ch)
+	cmd.SetArgs([]string{organization, "--project"})
+	err = cmd.Execute()
+	c.Assert(err, qt.IsNil)
+
+	root, err := config.RootGitRepoDir()
+	c.Assert(err, qt.IsNil)
+
+	data, err := os.ReadFile(filepath.Join(root, ".pscale.yml"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(data), qt.Equals, fmt.Sprintf("org: %s\n", organization))
+}
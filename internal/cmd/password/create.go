@@ -13,10 +13,11 @@ import (
 func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 	createReq := &ps.DatabaseBranchPasswordRequest{}
 	cmd := &cobra.Command{
-		Use:     "create <database> <branch> <name>",
-		Short:   "Create password to access a branch's data",
-		Args:    cmdutil.RequiredArgs("database", "branch", "name"),
-		Aliases: []string{"p"},
+		Use:               "create <database> <branch> <name>",
+		Short:             "Create password to access a branch's data",
+		Args:              cmdutil.RequiredArgs("database", "branch", "name"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		Aliases:           []string{"p"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
@@ -48,7 +49,7 @@ func CreateCmd(ch *cmdutil.Helper) *cobra.Command {
 			}
 
 			end()
-			if ch.Printer.Format() == printer.Human {
+			if ch.Printer.IsHuman() {
 				saveWarning := printer.BoldRed("Please save the values below as they will not be shown again")
 				ch.Printer.Printf("Password %s was successfully created in %s/%s.\n%s\n\n",
 					printer.BoldBlue(pass.Name), printer.BoldBlue(database), printer.BoldBlue(branch), saveWarning)
@@ -19,10 +19,11 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 	var force bool
 
 	cmd := &cobra.Command{
-		Use:     "delete <database> <branch> <password>",
-		Short:   "Delete a branch password",
-		Args:    cmdutil.RequiredArgs("database", "branch", "password"),
-		Aliases: []string{"rm"},
+		Use:               "delete <database> <branch> <password>",
+		Short:             "Delete a branch password",
+		Args:              cmdutil.RequiredArgs("database", "branch", "password"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		Aliases:           []string{"rm"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
@@ -35,7 +36,7 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 			}
 
 			if !force {
-				if ch.Printer.Format() != printer.Human {
+				if !ch.Printer.IsHuman() {
 					return fmt.Errorf("cannot delete password with the output format %q (run with -force to override)", ch.Printer.Format())
 				}
 
@@ -88,7 +89,7 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			end()
 
-			if ch.Printer.Format() == printer.Human {
+			if ch.Printer.IsHuman() {
 				ch.Printer.Printf("Password %s was successfully deleted from %s.\n",
 					printer.BoldBlue(password), printer.BoldBlue(branch))
 				return nil
@@ -14,10 +14,11 @@ import (
 // ListCmd encapsulates the command for listing passwords for a branch.
 func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "list <database> [branch]",
-		Short:   "List all passwords of a database",
-		Args:    cmdutil.RequiredArgs("database"),
-		Aliases: []string{"ls"},
+		Use:               "list <database> [branch]",
+		Short:             "List all passwords of a database",
+		Args:              cmdutil.RequiredArgs("database"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		Aliases:           []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			database := args[0]
@@ -68,7 +69,7 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 			}
 			end()
 
-			if len(passwords) == 0 && ch.Printer.Format() == printer.Human {
+			if len(passwords) == 0 && ch.Printer.IsHuman() {
 				ch.Printer.Printf("No passwords exist in %s.\n", forMsg)
 				return nil
 			}
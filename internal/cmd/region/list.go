@@ -38,8 +38,8 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			end()
 
-			if len(regions) == 0 && ch.Printer.Format() == printer.Human {
-				ch.Printer.Println("No regions have been created yet.")
+			if len(regions) == 0 && ch.Printer.IsHuman() {
+				ch.Printer.Printf("No regions are available for organization %s.\n", printer.BoldBlue(ch.Config.Organization))
 				return nil
 			}
 
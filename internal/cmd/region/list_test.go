@@ -56,3 +56,37 @@ func TestRegion_ListCmd(t *testing.T) {
 	c.Assert(svc.ListRegionsFnInvoked, qt.IsTrue)
 	c.Assert(buf.String(), qt.JSONEquals, regions)
 }
+
+func TestRegion_ListCmd_Empty(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.Human
+	p := printer.NewPrinter(&format)
+	p.SetHumanOutput(&buf)
+
+	svc := &mock.OrganizationsService{
+		ListRegionsFn: func(ctx context.Context, req *ps.ListOrganizationRegionsRequest) ([]*ps.Region, error) {
+			return []*ps.Region{}, nil
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Organizations: svc,
+			}, nil
+		},
+	}
+
+	cmd := ListCmd(ch)
+	err := cmd.Execute()
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(svc.ListRegionsFnInvoked, qt.IsTrue)
+	c.Assert(buf.String(), qt.Contains, "No regions are available for organization planetscale.\n")
+}
@@ -23,12 +23,14 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/planetscale/cli/internal/cmd/auditlog"
 	"github.com/planetscale/cli/internal/cmd/auth"
 	"github.com/planetscale/cli/internal/cmd/backup"
 	"github.com/planetscale/cli/internal/cmd/branch"
+	configcmd "github.com/planetscale/cli/internal/cmd/config"
 	"github.com/planetscale/cli/internal/cmd/connect"
 	"github.com/planetscale/cli/internal/cmd/database"
 	"github.com/planetscale/cli/internal/cmd/deployrequest"
@@ -76,21 +78,32 @@ func Execute(ctx context.Context, ver, commit, buildDate string) int {
 		}
 	}
 
+	// check for a new version while the command runs, so it doesn't delay
+	// the command's output.
+	printUpdateNotice := update.CheckVersion(ctx, ver)
+
 	err := runCmd(ctx, ver, commit, buildDate, &format, &debug)
-	if err == nil {
-		return 0
-	}
 
 	// print any user specific messages first
-	switch format {
-	case printer.JSON:
-		fmt.Fprintf(os.Stderr, `{"error": "%s"}`, err)
-	default:
-		if err := update.CheckVersion(ctx, ver); err != nil && debug {
+	if err != nil {
+		switch format {
+		case printer.JSON:
+			fmt.Fprintf(os.Stderr, `{"error": "%s"}`, err)
+		default:
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		}
+	}
+
+	// the notice isn't printed for JSON, which also keeps the check from
+	// being recorded, so it's shown by the next command that can print it.
+	if format != printer.JSON {
+		if err := printUpdateNotice(); err != nil && debug {
 			fmt.Fprintf(os.Stderr, "Updater error: %s\n", err)
 		}
+	}
 
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+	if err == nil {
+		return 0
 	}
 
 	// check if a sub command wants to return a specific exit code
@@ -128,12 +141,25 @@ func runCmd(ctx context.Context, ver, commit, buildDate string, format *printer.
 		"api-token", cfg.AccessToken, "The API token to use for authenticating against the PlanetScale API.")
 
 	rootCmd.PersistentFlags().VarP(printer.NewFormatValue(printer.Human, format), "format", "f",
-		"Show output in a specific format. Possible values: [human, json, csv]")
+		"Show output in a specific format. Possible values: [human, json, csv, yaml, json-lines, table-box]")
 	if err := viper.BindPFlag("format", rootCmd.PersistentFlags().Lookup("format")); err != nil {
 		return err
 	}
 	rootCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"human", "json", "csv"}, cobra.ShellCompDirectiveDefault
+		return []string{"human", "json", "csv", "yaml", "json-lines", "table-box"}, cobra.ShellCompDirectiveDefault
+	})
+
+	if err := apiFlags(rootCmd, cfg); err != nil {
+		return err
+	}
+
+	rootCmd.PersistentFlags().StringVar(&cfg.LogLevel, "log-level", "warn",
+		"Level of the API request logs written to stderr. Possible values: [debug, info, warn, error]. At debug every request is logged, at info only the failed ones.")
+	if err := viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level")); err != nil {
+		return err
+	}
+	rootCmd.RegisterFlagCompletionFunc("log-level", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"debug", "info", "warn", "error"}, cobra.ShellCompDirectiveDefault
 	})
 
 	rootCmd.PersistentFlags().BoolVar(debug, "debug", false, "Enable debug mode")
@@ -151,19 +177,38 @@ func runCmd(ctx context.Context, ver, commit, buildDate string, format *printer.
 	}
 	ch.SetDebug(debug)
 
+	var noHeaders bool
+	rootCmd.PersistentFlags().BoolVar(&noHeaders, "no-headers", false,
+		"Omit the header row when printing resources as a table")
+	ch.Printer.SetNoHeaders(&noHeaders)
+
+	var csvDelimiter string
+	rootCmd.PersistentFlags().StringVar(&csvDelimiter, "csv-delimiter", ",",
+		`Delimiter of the fields when printing resources as CSV, such as "\t" for tab-separated values`)
+	ch.Printer.SetCSVDelimiter(&csvDelimiter)
+
+	var csvNoHeader bool
+	rootCmd.PersistentFlags().BoolVar(&csvNoHeader, "csv-no-header", false,
+		"Omit the header row when printing resources as CSV")
+	ch.Printer.SetCSVNoHeader(&csvNoHeader)
+
 	// service token flags. they are hidden for now.
 	rootCmd.PersistentFlags().StringVar(&cfg.ServiceTokenName,
 		"service-token-name", "", "The Service Token name for authenticating.")
 	rootCmd.PersistentFlags().StringVar(&cfg.ServiceToken,
 		"service-token", "", "Service Token for authenticating.")
 
-	rootCmd.PersistentFlags().BoolVar(&color.NoColor, "no-color", false, "Disable color output")
+	// color.NoColor is already true when NO_COLOR is set, TERM is dumb or
+	// stdout isn't a terminal, so it's used as the default rather than
+	// overriding it.
+	rootCmd.PersistentFlags().BoolVar(&color.NoColor, "no-color", color.NoColor, "Disable color output. Also disabled when NO_COLOR is set or the output isn't a terminal")
 	if err := viper.BindPFlag("no-color", rootCmd.PersistentFlags().Lookup("no-color")); err != nil {
 		return err
 	}
 
 	// We don't want to show the default value
 	rootCmd.PersistentFlags().Lookup("api-token").DefValue = ""
+	rootCmd.PersistentFlags().Lookup("no-color").DefValue = "false"
 
 	loginCmd := auth.LoginCmd(ch)
 	loginCmd.Hidden = true
@@ -176,6 +221,7 @@ func runCmd(ctx context.Context, ver, commit, buildDate string, format *printer.
 	rootCmd.AddCommand(auth.AuthCmd(ch))
 	rootCmd.AddCommand(backup.BackupCmd(ch))
 	rootCmd.AddCommand(branch.BranchCmd(ch))
+	rootCmd.AddCommand(configcmd.ConfigCmd(ch))
 	rootCmd.AddCommand(connect.ConnectCmd(ch))
 	rootCmd.AddCommand(database.DatabaseCmd(ch))
 	rootCmd.AddCommand(deployrequest.DeployRequestCmd(ch))
@@ -190,6 +236,21 @@ func runCmd(ctx context.Context, ver, commit, buildDate string, format *printer.
 	return rootCmd.ExecuteContext(ctx)
 }
 
+// apiFlags defines the flags of the API client on cmd. Like --format, they're
+// bound to viper, so they can also be set with PLANETSCALE_API_TIMEOUT and
+// PLANETSCALE_API_RETRIES.
+func apiFlags(cmd *cobra.Command, cfg *config.Config) error {
+	cmd.PersistentFlags().DurationVar(&cfg.APITimeout, "api-timeout", 30*time.Second,
+		"Timeout for a single request to the PlanetScale API. Zero means no timeout.")
+	if err := viper.BindPFlag("api-timeout", cmd.PersistentFlags().Lookup("api-timeout")); err != nil {
+		return err
+	}
+
+	cmd.PersistentFlags().IntVar(&cfg.APIRetries, "api-retries", 0,
+		"How often to retry a request to the PlanetScale API that failed with a temporary server error.")
+	return viper.BindPFlag("api-retries", cmd.PersistentFlags().Lookup("api-retries"))
+}
+
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	if cfgFile != "" {
@@ -225,7 +286,7 @@ func initConfig() {
 
 	// Check for a project-local configuration file to merge in if the user
 	// has not specified a config file
-	if rootDir, err := config.RootGitRepoDir(); err == nil && cfgFile == "" {
+	if rootDir, err := config.ProjectConfigDir(); err == nil && cfgFile == "" {
 		viper.AddConfigPath(rootDir)
 		viper.SetConfigName(config.ProjectConfigFile())
 		viper.MergeInConfig() // nolint:errcheck
@@ -252,6 +313,10 @@ func presetRequiredFlags(cmd *cobra.Command) {
 	}
 
 	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if cmdutil.SkipsConfig(f) {
+			return
+		}
+
 		if viper.IsSet(f.Name) && viper.GetString(f.Name) != "" {
 			err = cmd.Flags().Set(f.Name, viper.GetString(f.Name))
 			if err != nil {
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/planetscale/cli/internal/cmd/deployrequest"
+	"github.com/planetscale/cli/internal/cmdutil"
+	"github.com/planetscale/cli/internal/config"
+	"github.com/planetscale/cli/internal/printer"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestPresetRequiredFlags_SkipConfig(t *testing.T) {
+	c := qt.New(t)
+
+	viper.Set("database", "mydb")
+	viper.Set("branch", "mybranch")
+	c.Cleanup(viper.Reset)
+
+	format := printer.Human
+	ch := &cmdutil.Helper{
+		Printer: printer.NewPrinter(&format),
+		Config:  &config.Config{},
+	}
+
+	cmd := deployrequest.ListCmd(ch)
+	presetRequiredFlags(cmd)
+
+	database, err := cmd.Flags().GetString("database")
+	c.Assert(err, qt.IsNil)
+	c.Assert(database, qt.Equals, "mydb")
+
+	branch, err := cmd.Flags().GetString("branch")
+	c.Assert(err, qt.IsNil)
+	c.Assert(branch, qt.Equals, "", qt.Commentf("a filter must not be read from pscale.yml"))
+}
+
+func TestAPIFlags_Env(t *testing.T) {
+	c := qt.New(t)
+
+	os.Setenv("PLANETSCALE_API_TIMEOUT", "5s")
+	os.Setenv("PLANETSCALE_API_RETRIES", "3")
+	c.Cleanup(func() {
+		os.Unsetenv("PLANETSCALE_API_TIMEOUT")
+		os.Unsetenv("PLANETSCALE_API_RETRIES")
+	})
+
+	viper.SetEnvPrefix("planetscale")
+	viper.SetEnvKeyReplacer(replacer)
+	viper.AutomaticEnv()
+	c.Cleanup(viper.Reset)
+
+	var tests = []struct {
+		name    string
+		args    []string
+		timeout time.Duration
+		retries int
+	}{
+		{
+			name:    "environment",
+			args:    []string{"sub"},
+			timeout: 5 * time.Second,
+			retries: 3,
+		},
+		{
+			name:    "flag wins over environment",
+			args:    []string{"sub", "--api-retries", "1"},
+			timeout: 5 * time.Second,
+			retries: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			root := &cobra.Command{Use: "pscale"}
+			c.Assert(apiFlags(root, cfg), qt.IsNil)
+
+			root.AddCommand(&cobra.Command{
+				Use: "sub",
+				Run: func(cmd *cobra.Command, args []string) {
+					presetRequiredFlags(cmd)
+				},
+			})
+
+			root.SetArgs(tt.args)
+			c.Assert(root.Execute(), qt.IsNil)
+			c.Assert(cfg.APITimeout, qt.Equals, tt.timeout)
+			c.Assert(cfg.APIRetries, qt.Equals, tt.retries)
+		})
+	}
+}
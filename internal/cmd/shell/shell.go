@@ -1,12 +1,16 @@
 package shell
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/planetscale/cli/internal/cmdutil"
@@ -27,13 +31,15 @@ func ShellCmd(ch *cmdutil.Helper) *cobra.Command {
 	var flags struct {
 		localAddr  string
 		remoteAddr string
+		query      string
 	}
 
 	cmd := &cobra.Command{
 		Use: "shell [database] [branch]",
 		// we only require database, because we deduct branch automatically
-		Args:  cmdutil.RequiredArgs("database"),
-		Short: "Open a MySQL shell instance to a database and branch",
+		Args:              cmdutil.RequiredArgs("database"),
+		ValidArgsFunction: cmdutil.DatabaseBranchCompletionFunc(ch),
+		Short:             "Open a MySQL shell instance to a database and branch",
 		Example: `The shell subcommand opens a secure MySQL shell instance to your database.
 
 It uses the MySQL command-line client ("mysql"), which needs to be installed.
@@ -46,7 +52,11 @@ If there are multiple branches for the given database, you'll be prompted to
 choose one. To open a shell instance to a specific branch, pass the branch as a
 second argument:
 
-  pscale shell mydatabase mybranch`,
+  pscale shell mydatabase mybranch
+
+To run a single statement and exit instead, pass it with --query:
+
+  pscale shell mydatabase mybranch --query "SHOW TABLES"`,
 		PersistentPreRunE: cmdutil.CheckAuthentication(ch.Config),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithCancel(cmd.Context())
@@ -54,7 +64,11 @@ second argument:
 
 			database := args[0]
 
-			if !printer.IsTTY || ch.Printer.Format() != printer.Human {
+			if flags.query != "" {
+				if ch.Printer.Format() == printer.CSV {
+					return errors.New("--query can't be used with the output format \"csv\"")
+				}
+			} else if !printer.IsTTY || !ch.Printer.IsHuman() {
 				if _, exists := os.LookupEnv("PSCALE_ALLOW_NONINTERACTIVE_SHELL"); !exists {
 					return errors.New("pscale shell only works in interactive mode")
 				}
@@ -152,6 +166,10 @@ second argument:
 				return err
 			}
 
+			if flags.query != "" {
+				return runQuery(ctx, ch, mysqlPath, host, port, flags.query)
+			}
+
 			mysqlArgs := []string{
 				"-u",
 				"root",
@@ -187,11 +205,115 @@ second argument:
 		"", "Local address to bind and listen for connections. By default the proxy binds to 127.0.0.1 with a random port.")
 	cmd.PersistentFlags().StringVar(&flags.remoteAddr, "remote-addr", "",
 		"PlanetScale Database remote network address. By default the remote address is populated automatically from the PlanetScale API.")
+	cmd.PersistentFlags().StringVarP(&flags.query, "query", "e", "",
+		"Run this SQL statement, print its result and exit instead of opening an interactive shell.")
 	cmd.MarkPersistentFlagRequired("org") // nolint:errcheck
 
 	return cmd
 }
 
+// runQuery runs a single statement with the mysql client. In the human
+// format the client prints its result as a table, otherwise the result is
+// read in batch mode and printed as a resource.
+func runQuery(ctx context.Context, ch *cmdutil.Helper, mysqlPath, host, port, query string) error {
+	args := []string{
+		"-u", "root",
+		"-h", host,
+		"-P", port,
+	}
+
+	// table-box output is drawn by the printer rather than by mysql
+	if ch.Printer.Format() == printer.Human {
+		c := exec.CommandContext(ctx, mysqlPath, append(args, "-t", "-e", query)...)
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+		return c.Run()
+	}
+
+	var out bytes.Buffer
+	c := exec.CommandContext(ctx, mysqlPath, append(args, "-B", "-e", query)...)
+	c.Stdout = &out
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return err
+	}
+
+	rows, err := parseBatchOutput(&out)
+	if err != nil {
+		return err
+	}
+
+	return ch.Printer.PrintResource(rows)
+}
+
+// parseBatchOutput parses the tab-separated output of the mysql client in
+// batch mode. The first line holds the column names. NULL values are returned
+// as nil.
+func parseBatchOutput(r io.Reader) ([]map[string]interface{}, error) {
+	rows := []map[string]interface{}{}
+
+	var columns []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if columns == nil {
+			columns = fields
+			continue
+		}
+
+		if len(fields) != len(columns) {
+			return nil, fmt.Errorf("mysql returned %d values for %d columns", len(fields), len(columns))
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, col := range columns {
+			if fields[i] == "NULL" {
+				row[col] = nil
+				continue
+			}
+			row[col] = unescapeBatchValue(fields[i])
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, scanner.Err()
+}
+
+// unescapeBatchValue reverts the escaping the mysql client applies to values
+// in batch mode.
+func unescapeBatchValue(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '0':
+			b.WriteByte(0)
+		case 'b':
+			b.WriteByte('\b')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
+
 // runProxy runs the sql-proxy with the given options.
 func runProxy(ctx context.Context, ch *cmdutil.Helper, proxyOpts proxy.Options, ready chan string) error {
 	p, err := proxy.NewClient(proxyOpts)
@@ -0,0 +1,29 @@
+package shell
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseBatchOutput(t *testing.T) {
+	c := qt.New(t)
+
+	out := "id\tname\tnotes\n1\tfoo\tNULL\n2\tbar\tline one\\nline\\ttwo\\\\\n"
+
+	rows, err := parseBatchOutput(strings.NewReader(out))
+	c.Assert(err, qt.IsNil)
+	c.Assert(rows, qt.DeepEquals, []map[string]interface{}{
+		{"id": "1", "name": "foo", "notes": nil},
+		{"id": "2", "name": "bar", "notes": "line one\nline\ttwo\\"},
+	})
+}
+
+func TestParseBatchOutput_Empty(t *testing.T) {
+	c := qt.New(t)
+
+	rows, err := parseBatchOutput(strings.NewReader(""))
+	c.Assert(err, qt.IsNil)
+	c.Assert(rows, qt.HasLen, 0)
+}
@@ -1,15 +1,22 @@
 package token
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/printer"
 	"github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/spf13/cobra"
 )
 
 func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
+	var force bool
+
 	cmd := &cobra.Command{
 		Use:   "delete <token>",
 		Short: "delete an entire service token in an organization",
@@ -26,6 +33,37 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			token := args[0]
 
+			if !force {
+				if format := ch.Printer.Format(); !format.IsHuman() {
+					return fmt.Errorf("cannot delete token with the output format %q (run with -force to override)", format.String())
+				}
+
+				if !printer.IsTTY {
+					return fmt.Errorf("cannot confirm deletion of token %q (run with -force to override)", token)
+				}
+
+				confirmationMessage := fmt.Sprintf("%s %s %s", printer.Bold("Please type"), printer.BoldBlue(token), printer.Bold("to confirm:"))
+
+				prompt := &survey.Input{
+					Message: confirmationMessage,
+				}
+
+				var userInput string
+				err := survey.AskOne(prompt, &userInput)
+				if err != nil {
+					if err == terminal.InterruptErr {
+						os.Exit(0)
+					} else {
+						return err
+					}
+				}
+
+				// If the confirmations don't match up, let's return an error.
+				if userInput != token {
+					return errors.New("incorrect token entered, skipping token deletion")
+				}
+			}
+
 			req := &planetscale.DeleteServiceTokenRequest{
 				ID:           token,
 				Organization: ch.Config.Organization,
@@ -37,8 +75,8 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 			if err := client.ServiceTokens.Delete(ctx, req); err != nil {
 				switch cmdutil.ErrCode(err) {
 				case planetscale.ErrNotFound:
-					return fmt.Errorf("token does not exist in organization %s",
-						printer.BoldBlue(ch.Config.Organization))
+					return fmt.Errorf("token %s does not exist in organization %s",
+						printer.BoldBlue(token), printer.BoldBlue(ch.Config.Organization))
 				default:
 					return cmdutil.HandleError(err)
 				}
@@ -46,7 +84,7 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			end()
 
-			if ch.Printer.Format() == printer.Human {
+			if ch.Printer.IsHuman() {
 				ch.Printer.Println("Token was successfully deleted.")
 				return nil
 			}
@@ -59,5 +97,6 @@ func DeleteCmd(ch *cmdutil.Helper) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVar(&force, "force", false, "Delete a service token without confirmation")
 	return cmd
 }
@@ -47,7 +47,7 @@ func TestServiceToken_DeleteCmd(t *testing.T) {
 	}
 
 	cmd := DeleteCmd(ch)
-	cmd.SetArgs([]string{token})
+	cmd.SetArgs([]string{token, "--force"})
 	err := cmd.Execute()
 
 	c.Assert(err, qt.IsNil)
@@ -58,3 +58,64 @@ func TestServiceToken_DeleteCmd(t *testing.T) {
 	}
 	c.Assert(buf.String(), qt.JSONEquals, res)
 }
+
+func TestServiceToken_DeleteCmd_NotFound(t *testing.T) {
+	c := qt.New(t)
+
+	var buf bytes.Buffer
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	svc := &mock.ServiceTokenService{
+		DeleteFn: func(ctx context.Context, req *ps.DeleteServiceTokenRequest) error {
+			return &ps.Error{Code: ps.ErrNotFound}
+		},
+	}
+
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				ServiceTokens: svc,
+			}, nil
+		},
+	}
+
+	cmd := DeleteCmd(ch)
+	cmd.SetArgs([]string{"123456", "--force"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, "token .*123456.* does not exist in organization .*planetscale.*")
+	c.Assert(buf.String(), qt.Equals, "")
+}
+
+func TestServiceToken_DeleteCmd_RequiresForce(t *testing.T) {
+	c := qt.New(t)
+
+	format := printer.JSON
+	p := printer.NewPrinter(&format)
+
+	svc := &mock.ServiceTokenService{}
+	ch := &cmdutil.Helper{
+		Printer: p,
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				ServiceTokens: svc,
+			}, nil
+		},
+	}
+
+	cmd := DeleteCmd(ch)
+	cmd.SetArgs([]string{"123456"})
+	err := cmd.Execute()
+
+	c.Assert(err, qt.ErrorMatches, `cannot delete token with the output format "json" \(run with -force to override\)`)
+	c.Assert(svc.DeleteFnInvoked, qt.IsFalse)
+}
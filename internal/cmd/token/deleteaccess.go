@@ -49,7 +49,7 @@ func DeleteAccessCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			end()
 
-			if ch.Printer.Format() == printer.Human {
+			if ch.Printer.IsHuman() {
 				ch.Printer.Printf("Accesses %v were successfully deleted.\n",
 					printer.BoldBlue(strings.Join(perms, ",")))
 				return nil
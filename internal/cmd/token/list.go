@@ -39,7 +39,7 @@ func ListCmd(ch *cmdutil.Helper) *cobra.Command {
 
 			end()
 
-			if len(tokens) == 0 && ch.Printer.Format() == printer.Human {
+			if len(tokens) == 0 && ch.Printer.IsHuman() {
 				ch.Printer.Println("No service tokens have been created yet.")
 				return nil
 			}
@@ -11,8 +11,13 @@ import (
 // TokenCmd encapsulates the command for running snapshots.
 func TokenCmd(ch *cmdutil.Helper) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:               "service-token <action>",
-		Short:             "Create, list, and manage access for service tokens",
+		Use:   "service-token <action>",
+		Short: "Create, list, and manage access for service tokens",
+		Long: `Create, list, and manage access for service tokens.
+
+To authenticate as a service token instead of your user account, pass its ID
+and value with the --service-token-name and --service-token flags of any
+command.`,
 		PersistentPreRunE: cmdutil.CheckAuthentication(ch.Config),
 	}
 
@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/planetscale/cli/internal/cmdutil"
-	"github.com/planetscale/cli/internal/printer"
 
 	"github.com/spf13/cobra"
 )
@@ -18,7 +17,7 @@ func VersionCmd(ch *cmdutil.Helper, ver, commit, buildDate string) *cobra.Comman
 		// need to be displayed.
 		Hidden: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if ch.Printer.Format() == printer.Human {
+			if ch.Printer.IsHuman() {
 				ch.Printer.Println(Format(ver, commit, buildDate))
 				return nil
 			}
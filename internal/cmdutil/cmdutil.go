@@ -14,6 +14,7 @@ import (
 	ps "github.com/planetscale/planetscale-go/planetscale"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	exec "golang.org/x/sys/execabs"
@@ -179,3 +180,20 @@ func MySQLClientPath() (string, error) {
 
 	return "", fmt.Errorf("%s\nTo install, follow the instructions: %s", msg, installURL)
 }
+
+// skipConfigAnnotation is the flag annotation set by SkipConfig.
+const skipConfigAnnotation = "pscale_skip_config"
+
+// SkipConfig marks the flag with the given name so it's never filled in from
+// the configuration files. Use it for flags that share their name with a
+// setting, such as a filter, where a value in pscale.yml would silently
+// change the result of the command.
+func SkipConfig(cmd *cobra.Command, name string) {
+	cmd.Flags().SetAnnotation(name, skipConfigAnnotation, []string{"true"}) // nolint:errcheck
+}
+
+// SkipsConfig reports whether the given flag was marked with SkipConfig.
+func SkipsConfig(f *pflag.Flag) bool {
+	_, ok := f.Annotations[skipConfigAnnotation]
+	return ok
+}
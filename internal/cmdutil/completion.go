@@ -0,0 +1,111 @@
+package cmdutil
+
+import (
+	ps "github.com/planetscale/planetscale-go/planetscale"
+
+	"github.com/spf13/cobra"
+)
+
+// CompletionFunc is the signature of a cobra ValidArgsFunction.
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// DatabaseCompletionFunc returns a completion function that completes the
+// first positional argument with the databases of the current organization.
+func DatabaseCompletionFunc(ch *Helper) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return completeDatabases(cmd, ch)
+	}
+}
+
+// DatabaseBranchCompletionFunc returns a completion function that completes
+// the first positional argument with the databases of the current
+// organization, and the second one with the branches of that database.
+func DatabaseBranchCompletionFunc(ch *Helper) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			return completeDatabases(cmd, ch)
+		case 1:
+			return completeBranches(cmd, ch, args[0])
+		default:
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+}
+
+func completeDatabases(cmd *cobra.Command, ch *Helper) ([]string, cobra.ShellCompDirective) {
+	client, err := ch.Client()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	org, ok := completionOrg(ch)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	databases, err := client.Databases.List(cmd.Context(), &ps.ListDatabasesRequest{
+		Organization: org,
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	candidates := make([]string, 0, len(databases))
+	for _, db := range databases {
+		candidates = append(candidates, db.Name)
+	}
+
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeBranches(cmd *cobra.Command, ch *Helper, database string) ([]string, cobra.ShellCompDirective) {
+	client, err := ch.Client()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	org, ok := completionOrg(ch)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	branches, err := client.DatabaseBranches.List(cmd.Context(), &ps.ListDatabaseBranchesRequest{
+		Organization: org,
+		Database:     database,
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	candidates := make([]string, 0, len(branches))
+	for _, b := range branches {
+		candidates = append(candidates, b.Name)
+	}
+
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionOrg returns the organization to complete names for. The --org
+// flag isn't always bound when completing, so it falls back to the default
+// config file.
+func completionOrg(ch *Helper) (string, bool) {
+	if ch.Config.Organization != "" {
+		return ch.Config.Organization, true
+	}
+
+	if ch.ConfigFS == nil {
+		return "", false
+	}
+
+	cfg, err := ch.ConfigFS.DefaultConfig()
+	if err != nil {
+		return "", false
+	}
+
+	return cfg.Organization, cfg.Organization != ""
+}
@@ -0,0 +1,91 @@
+package cmdutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/planetscale/cli/internal/config"
+	"github.com/planetscale/cli/internal/mock"
+	ps "github.com/planetscale/planetscale-go/planetscale"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/spf13/cobra"
+)
+
+func TestDatabaseBranchCompletionFunc(t *testing.T) {
+	c := qt.New(t)
+
+	org := "planetscale"
+
+	dbSvc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			c.Assert(req.Organization, qt.Equals, org)
+			return []*ps.Database{{Name: "foo"}, {Name: "bar"}}, nil
+		},
+	}
+
+	branchSvc := &mock.DatabaseBranchesService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabaseBranchesRequest) ([]*ps.DatabaseBranch, error) {
+			c.Assert(req.Organization, qt.Equals, org)
+			c.Assert(req.Database, qt.Equals, "foo")
+			return []*ps.DatabaseBranch{{Name: "main"}, {Name: "dev"}}, nil
+		},
+	}
+
+	ch := &Helper{
+		Config: &config.Config{
+			Organization: org,
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases:        dbSvc,
+				DatabaseBranches: branchSvc,
+			}, nil
+		},
+	}
+
+	cmd := &cobra.Command{}
+	fn := DatabaseBranchCompletionFunc(ch)
+
+	candidates, directive := fn(cmd, nil, "")
+	c.Assert(candidates, qt.DeepEquals, []string{"foo", "bar"})
+	c.Assert(directive, qt.Equals, cobra.ShellCompDirectiveNoFileComp)
+
+	candidates, directive = fn(cmd, []string{"foo"}, "")
+	c.Assert(candidates, qt.DeepEquals, []string{"main", "dev"})
+	c.Assert(directive, qt.Equals, cobra.ShellCompDirectiveNoFileComp)
+
+	candidates, _ = fn(cmd, []string{"foo", "main"}, "")
+	c.Assert(candidates, qt.HasLen, 0)
+}
+
+func TestDatabaseCompletionFunc(t *testing.T) {
+	c := qt.New(t)
+
+	dbSvc := &mock.DatabaseService{
+		ListFn: func(ctx context.Context, req *ps.ListDatabasesRequest) ([]*ps.Database, error) {
+			return []*ps.Database{{Name: "foo"}}, nil
+		},
+	}
+
+	ch := &Helper{
+		Config: &config.Config{
+			Organization: "planetscale",
+		},
+		Client: func() (*ps.Client, error) {
+			return &ps.Client{
+				Databases: dbSvc,
+			}, nil
+		},
+	}
+
+	cmd := &cobra.Command{}
+	fn := DatabaseCompletionFunc(ch)
+
+	candidates, _ := fn(cmd, nil, "")
+	c.Assert(candidates, qt.DeepEquals, []string{"foo"})
+
+	candidates, _ = fn(cmd, []string{"foo"}, "")
+	c.Assert(candidates, qt.HasLen, 0)
+	c.Assert(dbSvc.ListFnInvoked, qt.IsTrue)
+}
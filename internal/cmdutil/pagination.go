@@ -0,0 +1,97 @@
+package cmdutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 100
+)
+
+// Pagination holds the --page and --limit flags of a list command. The API
+// returns all results at once, so the pages are cut from the full list.
+//
+// The human output is paged by default. Other formats list all results
+// unless --page or --limit is set, so scripts don't silently get a single
+// page. A limit of 0 lists all results.
+type Pagination struct {
+	Page  int
+	Limit int
+
+	// all is set by Validate if all results are listed.
+	all bool
+}
+
+// AddFlags adds the --page and --limit flags to cmd.
+func (p *Pagination) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&p.Page, "page", 1, "Page of results to list, starting with 1")
+	cmd.Flags().IntVar(&p.Limit, "limit", defaultPageLimit,
+		fmt.Sprintf("Number of results to list per page, at most %d. 0 lists all of them. "+
+			"Formats other than human list all results unless --page or --limit is set", maxPageLimit))
+}
+
+// Validate checks the values of the flags of cmd and decides whether all
+// results are listed, which depends on whether the output is human.
+func (p *Pagination) Validate(cmd *cobra.Command, human bool) error {
+	if p.Page < 1 {
+		return errors.New("--page must be at least 1")
+	}
+	if p.Limit < 0 || p.Limit > maxPageLimit {
+		return fmt.Errorf("--limit must be between 0 and %d", maxPageLimit)
+	}
+	if p.Limit == 0 && p.Page > 1 {
+		return errors.New("--page can't be used with --limit 0")
+	}
+
+	paged := human || cmd.Flags().Changed("page") || cmd.Flags().Changed("limit")
+	p.all = p.Limit == 0 || !paged
+	return nil
+}
+
+// Bounds returns the start and end index of the current page in a list of n
+// results.
+func (p *Pagination) Bounds(n int) (start, end int) {
+	if p.all {
+		return 0, n
+	}
+
+	start = (p.Page - 1) * p.Limit
+	if start > n {
+		start = n
+	}
+
+	end = start + p.Limit
+	if end > n {
+		end = n
+	}
+
+	return start, end
+}
+
+// Footer returns the line to print below the current page of a list of n
+// results. It's empty if all results fit in a single page.
+func (p *Pagination) Footer(n int) string {
+	if p.all {
+		return ""
+	}
+
+	start, end := p.Bounds(n)
+	if p.Page == 1 && end == n {
+		return ""
+	}
+
+	if start == end {
+		return fmt.Sprintf("Page %d is empty, there are %d results in total.\n", p.Page, n)
+	}
+
+	footer := fmt.Sprintf("Showing page %d, %d results.", p.Page, end-start)
+	if end < n {
+		footer += fmt.Sprintf(" Run with --page %d to see more.", p.Page+1)
+	}
+
+	return footer + "\n"
+}
@@ -0,0 +1,66 @@
+package cmdutil
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/spf13/cobra"
+)
+
+func TestPagination(t *testing.T) {
+	c := qt.New(t)
+
+	p := &Pagination{Page: 1, Limit: 50}
+	start, end := p.Bounds(120)
+	c.Assert([]int{start, end}, qt.DeepEquals, []int{0, 50})
+	c.Assert(p.Footer(120), qt.Equals, "Showing page 1, 50 results. Run with --page 2 to see more.\n")
+	c.Assert(p.Footer(50), qt.Equals, "")
+
+	p.Page = 3
+	start, end = p.Bounds(120)
+	c.Assert([]int{start, end}, qt.DeepEquals, []int{100, 120})
+	c.Assert(p.Footer(120), qt.Equals, "Showing page 3, 20 results.\n")
+
+	p.Page = 4
+	start, end = p.Bounds(120)
+	c.Assert([]int{start, end}, qt.DeepEquals, []int{120, 120})
+	c.Assert(p.Footer(120), qt.Equals, "Page 4 is empty, there are 120 results in total.\n")
+
+	cmd := &cobra.Command{}
+	c.Assert((&Pagination{Page: 0, Limit: 50}).Validate(cmd, true), qt.ErrorMatches, "--page must be at least 1")
+	c.Assert((&Pagination{Page: 1, Limit: 101}).Validate(cmd, true), qt.ErrorMatches, "--limit must be between 0 and 100")
+	c.Assert((&Pagination{Page: 2, Limit: 0}).Validate(cmd, true), qt.ErrorMatches, "--page can't be used with --limit 0")
+}
+
+func TestPagination_All(t *testing.T) {
+	c := qt.New(t)
+
+	var tests = []struct {
+		name  string
+		args  []string
+		human bool
+		all   bool
+	}{
+		{name: "human", human: true, all: false},
+		{name: "human with --limit 0", args: []string{"--limit", "0"}, human: true, all: true},
+		{name: "machine format", human: false, all: true},
+		{name: "machine format with --page", args: []string{"--page", "2"}, human: false, all: false},
+		{name: "machine format with --limit", args: []string{"--limit", "10"}, human: false, all: false},
+	}
+
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			var p Pagination
+			cmd := &cobra.Command{}
+			p.AddFlags(cmd)
+			c.Assert(cmd.ParseFlags(tt.args), qt.IsNil)
+			c.Assert(p.Validate(cmd, tt.human), qt.IsNil)
+
+			start, end := p.Bounds(120)
+			c.Assert(start == 0 && end == 120, qt.Equals, tt.all)
+			if tt.all {
+				c.Assert(p.Footer(120), qt.Equals, "")
+			}
+		})
+	}
+}
@@ -5,13 +5,18 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
+	"time"
 
 	ps "github.com/planetscale/planetscale-go/planetscale"
 
+	"github.com/hashicorp/go-cleanhttp"
 	"github.com/mitchellh/go-homedir"
+	"go.uber.org/zap"
 	exec "golang.org/x/sys/execabs"
 )
 
@@ -31,6 +36,16 @@ type Config struct {
 	ServiceTokenName string
 	ServiceToken     string
 
+	// APITimeout limits how long a single API request may take. Zero means
+	// no limit.
+	APITimeout time.Duration
+	// APIRetries is how often an API request is retried after a temporary
+	// server error.
+	APIRetries int
+	// LogLevel is the level of the API request logs, one of debug, info,
+	// warn or error.
+	LogLevel string
+
 	// Project Configuration
 	Database string
 	Branch   string
@@ -76,6 +91,30 @@ func (c *Config) NewClientFromConfig(clientOpts ...ps.ClientOption) (*ps.Client,
 	opts := []ps.ClientOption{
 		ps.WithBaseURL(c.BaseURL),
 	}
+
+	level, err := parseLogLevel(c.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	var transport http.RoundTripper = cleanhttp.DefaultPooledTransport()
+	customTransport := false
+	if level <= zap.InfoLevel {
+		// every retry is logged as a request of its own.
+		transport = newLogTransport(transport, newStderrLogger(level))
+		customTransport = true
+	}
+	if c.APITimeout > 0 || c.APIRetries > 0 {
+		transport = newRetryTransport(transport, c.APITimeout, c.APIRetries)
+		customTransport = true
+	}
+	if customTransport {
+		// this has to come before the token options, as they wrap the
+		// client's transport.
+		opts = append(opts, ps.WithHTTPClient(&http.Client{
+			Transport: transport,
+		}))
+	}
 	if c.ServiceToken != "" && c.ServiceTokenName != "" {
 		opts = append(opts, ps.WithServiceToken(c.ServiceTokenName, c.ServiceToken))
 	} else {
@@ -106,16 +145,63 @@ func AccessTokenPath() (string, error) {
 	return path.Join(dir, "access-token"), nil
 }
 
-// ProjectConfigPath returns the path of a configuration inside a Git
-// repository.
+// RefreshTokenPath is the path for the refresh token file
+func RefreshTokenPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(dir, "refresh-token"), nil
+}
+
+// ProjectConfigPath returns the path of the project configuration. See
+// ProjectConfigDir for how the project directory is found.
 func ProjectConfigPath() (string, error) {
-	basePath, err := RootGitRepoDir()
+	basePath, err := ProjectConfigDir()
 	if err == nil {
 		return path.Join(basePath, projectConfigName), nil
 	}
 	return path.Join("", projectConfigName), nil
 }
 
+// ProjectConfigDir returns the root directory of the current project. It's
+// the closest directory, starting at the working directory, that contains a
+// project configuration file. If there is none, the root of the Git
+// repository is used, so projects don't need to be under Git to have a
+// project configuration.
+func ProjectConfigDir() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	if dir, ok := findUp(wd, projectConfigName); ok {
+		return dir, nil
+	}
+
+	return RootGitRepoDir()
+}
+
+// findUp returns the closest directory, starting at dir and walking up to the
+// file system root, that contains an entry with the given name.
+func findUp(dir, name string) (string, bool) {
+	dir = filepath.Clean(dir)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return dir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// RootGitRepoDir returns the root directory of the Git repository the working
+// directory is in.
 func RootGitRepoDir() (string, error) {
 	var tl = []string{"rev-parse", "--show-toplevel"}
 	out, err := exec.Command("git", tl...).CombinedOutput()
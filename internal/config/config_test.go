@@ -0,0 +1,53 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestProjectConfigDir(t *testing.T) {
+	c := qt.New(t)
+
+	// resolve symlinks, as the working directory is reported with them
+	// resolved on some systems, such as macOS.
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	c.Assert(err, qt.IsNil)
+
+	nested := filepath.Join(root, "a", "b")
+	c.Assert(os.MkdirAll(nested, 0755), qt.IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(root, projectConfigName), []byte("org: planetscale\n"), 0644), qt.IsNil)
+
+	wd, err := os.Getwd()
+	c.Assert(err, qt.IsNil)
+	c.Cleanup(func() { os.Chdir(wd) }) // nolint:errcheck
+	c.Assert(os.Chdir(nested), qt.IsNil)
+
+	dir, err := ProjectConfigDir()
+	c.Assert(err, qt.IsNil)
+	c.Assert(dir, qt.Equals, root)
+
+	path, err := ProjectConfigPath()
+	c.Assert(err, qt.IsNil)
+	c.Assert(path, qt.Equals, filepath.Join(root, projectConfigName))
+}
+
+func TestFindUp(t *testing.T) {
+	c := qt.New(t)
+
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	c.Assert(os.MkdirAll(nested, 0755), qt.IsNil)
+
+	_, ok := findUp(nested, "does-not-exist.yml")
+	c.Assert(ok, qt.IsFalse)
+
+	c.Assert(ioutil.WriteFile(filepath.Join(root, "a", projectConfigName), nil, 0644), qt.IsNil)
+
+	dir, ok := findUp(nested, projectConfigName)
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(dir, qt.Equals, filepath.Join(root, "a"))
+}
@@ -0,0 +1,248 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// retryBaseDelay is the delay before the first retry. It doubles with every
+// following retry.
+const retryBaseDelay = 500 * time.Millisecond
+
+// maxRetryAfter is the longest Retry-After the API can ask for that is still
+// waited for. A response that asks for more is returned as is.
+const maxRetryAfter = time.Minute
+
+// retryTransport is an http.RoundTripper that limits the time of every
+// request and retries requests that failed with a temporary server error.
+type retryTransport struct {
+	rt      http.RoundTripper
+	timeout time.Duration
+	retries int
+
+	// backoff returns how long to wait before the given retry, starting
+	// with 1.
+	backoff func(retry int) time.Duration
+}
+
+func newRetryTransport(rt http.RoundTripper, timeout time.Duration, retries int) *retryTransport {
+	return &retryTransport{
+		rt:      rt,
+		timeout: timeout,
+		retries: retries,
+		backoff: func(retry int) time.Duration {
+			return retryBaseDelay << (retry - 1)
+		},
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for retry := 0; ; retry++ {
+		resp, err := t.roundTrip(req)
+		if retry >= t.retries || !canRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(retry + 1)
+		if d, ok := retryAfter(resp, time.Now()); ok {
+			if d > maxRetryAfter {
+				return resp, nil
+			}
+			delay = d
+		}
+
+		if resp != nil {
+			// drain the body so the connection can be reused.
+			io.Copy(io.Discard, resp.Body) // nolint:errcheck
+			resp.Body.Close()
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// roundTrip sends a single request. If a timeout is set, it applies until
+// the response body is closed.
+func (t *retryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.rt.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.rt.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// canRetry reports whether the request can be sent again after the given
+// response.
+func canRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil || resp == nil {
+		return false
+	}
+
+	// a request with a body can only be sent again if the body can be
+	// read again.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter returns how long the Retry-After header of a 429 or 503
+// response asks to wait before the next request. It's given either in
+// seconds or as an HTTP date.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	date, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+
+	if d := date.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// cancelOnClose cancels the request's context once its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// logTransport is an http.RoundTripper that logs every request along with
+// its response status. Successful requests are logged at the debug level,
+// failed ones at the info level.
+type logTransport struct {
+	rt  http.RoundTripper
+	log *zap.Logger
+}
+
+func newLogTransport(rt http.RoundTripper, log *zap.Logger) *logTransport {
+	return &logTransport{
+		rt:  rt,
+		log: log,
+	}
+}
+
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.rt.RoundTrip(req)
+
+	fields := []zap.Field{
+		zap.String("method", req.Method),
+		zap.String("url", req.URL.String()),
+		zap.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		t.log.Info("API request failed", append(fields, zap.Error(err))...)
+		return nil, err
+	}
+
+	fields = append(fields, zap.Int("status", resp.StatusCode))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		t.log.Info("API request", fields...)
+	} else if ce := t.log.Check(zap.DebugLevel, "API request"); ce != nil {
+		ce.Write(append(fields, zap.Any("headers", redactHeaders(req.Header)))...)
+	}
+
+	return resp, nil
+}
+
+// redactHeaders returns a copy of the headers with the credentials hidden.
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	if out.Get("Authorization") != "" {
+		out.Set("Authorization", "REDACTED")
+	}
+	return out
+}
+
+// parseLogLevel parses the level of the API request logs. An empty level is
+// the default, warn.
+func parseLogLevel(level string) (zapcore.Level, error) {
+	switch level {
+	case "debug":
+		return zap.DebugLevel, nil
+	case "info":
+		return zap.InfoLevel, nil
+	case "", "warn":
+		return zap.WarnLevel, nil
+	case "error":
+		return zap.ErrorLevel, nil
+	default:
+		return 0, fmt.Errorf("invalid log level %q, must be one of debug, info, warn or error", level)
+	}
+}
+
+// newStderrLogger returns a logger that writes the messages of the given
+// level and above to stderr, so they don't mix with the command's output.
+func newStderrLogger(level zapcore.Level) *zap.Logger {
+	encoderCfg := zapcore.EncoderConfig{
+		MessageKey:     "msg",
+		LevelKey:       "level",
+		TimeKey:        "T",
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.RFC3339TimeEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}
+
+	return zap.New(zapcore.NewCore(zapcore.NewConsoleEncoder(encoderCfg), zapcore.Lock(os.Stderr), level))
+}
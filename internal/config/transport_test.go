@@ -0,0 +1,241 @@
+package config
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRetryTransport(t *testing.T) {
+	c := qt.New(t)
+
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		c.Assert(string(body), qt.Equals, "payload")
+
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok")) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	rt := newRetryTransport(http.DefaultTransport, time.Second, 2)
+	rt.backoff = func(int) time.Duration { return 0 }
+	client := &http.Client{Transport: rt}
+
+	resp, err := client.Post(srv.URL, "text/plain", strings.NewReader("payload"))
+	c.Assert(err, qt.IsNil)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, qt.IsNil)
+	c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+	c.Assert(string(body), qt.Equals, "ok")
+	c.Assert(calls, qt.Equals, 3)
+}
+
+func TestRetryTransport_GivesUp(t *testing.T) {
+	c := qt.New(t)
+
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	rt := newRetryTransport(http.DefaultTransport, 0, 1)
+	rt.backoff = func(int) time.Duration { return 0 }
+	client := &http.Client{Transport: rt}
+
+	resp, err := client.Get(srv.URL)
+	c.Assert(err, qt.IsNil)
+	resp.Body.Close()
+
+	c.Assert(resp.StatusCode, qt.Equals, http.StatusTooManyRequests)
+	c.Assert(calls, qt.Equals, 2)
+}
+
+func TestRetryTransport_RetryAfter(t *testing.T) {
+	c := qt.New(t)
+
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if calls == 2 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok")) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	// the backoff would time out the test, so the first retry must wait for
+	// the Retry-After of the response instead.
+	rt := newRetryTransport(http.DefaultTransport, 0, 5)
+	rt.backoff = func(int) time.Duration { return time.Hour }
+	client := &http.Client{Transport: rt}
+
+	resp, err := client.Get(srv.URL)
+	c.Assert(err, qt.IsNil)
+	resp.Body.Close()
+
+	// an hour is longer than is waited for, so the response is returned.
+	c.Assert(resp.StatusCode, qt.Equals, http.StatusTooManyRequests)
+	c.Assert(calls, qt.Equals, 2)
+}
+
+func TestRetryAfter(t *testing.T) {
+	c := qt.New(t)
+
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name       string
+		statusCode int
+		header     string
+		want       time.Duration
+		ok         bool
+	}{
+		{
+			name:       "seconds",
+			statusCode: http.StatusTooManyRequests,
+			header:     "5",
+			want:       5 * time.Second,
+			ok:         true,
+		},
+		{
+			name:       "date",
+			statusCode: http.StatusServiceUnavailable,
+			header:     now.Add(10 * time.Second).Format(http.TimeFormat),
+			want:       10 * time.Second,
+			ok:         true,
+		},
+		{
+			name:       "date in the past",
+			statusCode: http.StatusServiceUnavailable,
+			header:     now.Add(-10 * time.Second).Format(http.TimeFormat),
+			want:       0,
+			ok:         true,
+		},
+		{
+			name:       "no header",
+			statusCode: http.StatusTooManyRequests,
+		},
+		{
+			name:       "invalid header",
+			statusCode: http.StatusTooManyRequests,
+			header:     "soon",
+		},
+		{
+			name:       "other status",
+			statusCode: http.StatusBadGateway,
+			header:     "5",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.statusCode, Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+
+			d, ok := retryAfter(resp, now)
+			c.Assert(ok, qt.Equals, tt.ok)
+			c.Assert(d, qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestRetryTransport_Timeout(t *testing.T) {
+	c := qt.New(t)
+
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, 50*time.Millisecond, 0)}
+
+	_, err := client.Get(srv.URL)
+	c.Assert(err, qt.ErrorMatches, ".*context deadline exceeded.*")
+}
+
+func TestLogTransport(t *testing.T) {
+	c := qt.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("ok")) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	core, logs := observer.New(zap.DebugLevel)
+	client := &http.Client{Transport: newLogTransport(http.DefaultTransport, zap.New(core))}
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/ok", nil)
+	c.Assert(err, qt.IsNil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := client.Do(req)
+	c.Assert(err, qt.IsNil)
+	resp.Body.Close()
+
+	resp, err = client.Get(srv.URL + "/missing")
+	c.Assert(err, qt.IsNil)
+	resp.Body.Close()
+
+	entries := logs.AllUntimed()
+	c.Assert(entries, qt.HasLen, 2)
+
+	c.Assert(entries[0].Level, qt.Equals, zap.DebugLevel)
+	fields := entries[0].ContextMap()
+	c.Assert(fields["method"], qt.Equals, "GET")
+	c.Assert(fields["url"], qt.Equals, srv.URL+"/ok")
+	c.Assert(fields["status"], qt.Equals, int64(http.StatusOK))
+	c.Assert(fields["headers"], qt.DeepEquals, http.Header{"Authorization": {"REDACTED"}})
+	c.Assert(req.Header.Get("Authorization"), qt.Equals, "Bearer secret")
+
+	c.Assert(entries[1].Level, qt.Equals, zap.InfoLevel)
+	c.Assert(entries[1].ContextMap()["status"], qt.Equals, int64(http.StatusNotFound))
+}
+
+func TestParseLogLevel(t *testing.T) {
+	c := qt.New(t)
+
+	level, err := parseLogLevel("")
+	c.Assert(err, qt.IsNil)
+	c.Assert(level, qt.Equals, zap.WarnLevel)
+
+	level, err = parseLogLevel("debug")
+	c.Assert(err, qt.IsNil)
+	c.Assert(level, qt.Equals, zap.DebugLevel)
+
+	_, err = parseLogLevel("trace")
+	c.Assert(err, qt.ErrorMatches, `invalid log level "trace", must be one of debug, info, warn or error`)
+}
@@ -0,0 +1,89 @@
+package printer
+
+import (
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/lensesio/tableprinter"
+	"github.com/mattn/go-runewidth"
+)
+
+// printBox prints the given resource as a table drawn with Unicode
+// box-drawing characters. The headers and rows are parsed the same way as for
+// the human table, so both show the same columns.
+func printBox(w io.Writer, v interface{}, noHeaders bool) {
+	rv := reflect.ValueOf(v)
+	if kind := rv.Kind(); kind == reflect.Interface || kind == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	parser := tableprinter.WhichParser(rv.Type())
+	if parser == nil {
+		return
+	}
+
+	headers, rows, _ := parser.Parse(rv, nil)
+	if len(headers) == 0 {
+		return
+	}
+
+	// the human table formats its headers the same way.
+	for i, h := range headers {
+		headers[i] = strings.ToUpper(strings.ReplaceAll(h, "_", " "))
+	}
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = runewidth.StringWidth(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) && runewidth.StringWidth(cell) > widths[i] {
+				widths[i] = runewidth.StringWidth(cell)
+			}
+		}
+	}
+
+	line := func(left, middle, right string) {
+		var b strings.Builder
+		b.WriteString(left)
+		for i, width := range widths {
+			if i > 0 {
+				b.WriteString(middle)
+			}
+			b.WriteString(strings.Repeat("─", width+2))
+		}
+		b.WriteString(right)
+		b.WriteString("\n")
+		io.WriteString(w, b.String()) // nolint:errcheck
+	}
+
+	row := func(cells []string) {
+		var b strings.Builder
+		b.WriteString("│")
+		for i, width := range widths {
+			var cell string
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			b.WriteString(" ")
+			b.WriteString(runewidth.FillRight(cell, width))
+			b.WriteString(" │")
+		}
+		b.WriteString("\n")
+		io.WriteString(w, b.String()) // nolint:errcheck
+	}
+
+	line("┌", "┬", "┐")
+	if !noHeaders {
+		row(headers)
+		if len(rows) > 0 {
+			line("├", "┼", "┤")
+		}
+	}
+	for _, r := range rows {
+		row(r)
+	}
+	line("└", "┴", "┘")
+}
@@ -1,20 +1,24 @@
 package printer
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
 	"github.com/gocarina/gocsv"
 	"github.com/lensesio/tableprinter"
 	"github.com/mattn/go-isatty"
+	"gopkg.in/yaml.v2"
 )
 
 var IsTTY = isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())
@@ -28,6 +32,13 @@ const (
 	Human Format = iota
 	JSON
 	CSV
+	YAML
+	// JSONLines prints every element of a list as a JSON object on its own
+	// line.
+	JSONLines
+	// TableBox prints resources like Human, but draws tables with Unicode
+	// box-drawing characters.
+	TableBox
 )
 
 // NewFormatValue is used to define a flag that can be used to define a custom
@@ -45,6 +56,12 @@ func (f *Format) String() string {
 		return "json"
 	case CSV:
 		return "csv"
+	case YAML:
+		return "yaml"
+	case JSONLines:
+		return "json-lines"
+	case TableBox:
+		return "table-box"
 	}
 
 	return "unknown format"
@@ -59,9 +76,15 @@ func (f *Format) Set(s string) error {
 		v = JSON
 	case "csv":
 		v = CSV
+	case "yaml":
+		v = YAML
+	case "json-lines", "ndjson":
+		v = JSONLines
+	case "table-box":
+		v = TableBox
 	default:
 		return fmt.Errorf("failed to parse Format: %q. Valid values: %+v",
-			s, []string{"human", "json", "csv"})
+			s, []string{"human", "json", "csv", "yaml", "json-lines", "table-box"})
 	}
 
 	*f = Format(v)
@@ -72,12 +95,22 @@ func (f *Format) Type() string {
 	return "string"
 }
 
+// IsHuman reports whether f prints human readable text, which is the case
+// for Human and TableBox.
+func (f Format) IsHuman() bool {
+	return f == Human || f == TableBox
+}
+
 // Printer is used to print information to the defined output.
 type Printer struct {
 	humanOut    io.Writer
 	resourceOut io.Writer
 
-	format *Format
+	format    *Format
+	noHeaders *bool
+
+	csvDelimiter *string
+	csvNoHeader  *bool
 }
 
 // NewPrinter returns a new Printer for the given output and format.
@@ -110,7 +143,7 @@ func (p *Printer) out() io.Writer {
 		return p.humanOut
 	}
 
-	if *p.format == Human {
+	if p.format.IsHuman() {
 		return color.Output
 	}
 
@@ -144,6 +177,11 @@ func (p *Printer) PrintProgress(message string) func() {
 // Format returns the format that was set for this printer
 func (p *Printer) Format() Format { return *p.format }
 
+// IsHuman reports whether the format of this printer prints human readable
+// text. Use it instead of comparing Format with Human, so TableBox is
+// treated the same.
+func (p *Printer) IsHuman() bool { return p.format.IsHuman() }
+
 // SetHumanOutput sets the output for human readable messages.
 func (p *Printer) SetHumanOutput(out io.Writer) {
 	p.humanOut = out
@@ -154,6 +192,50 @@ func (p *Printer) SetResourceOutput(out io.Writer) {
 	p.resourceOut = out
 }
 
+// SetNoHeaders controls whether the header row is omitted when printing
+// resources as a table.
+func (p *Printer) SetNoHeaders(noHeaders *bool) {
+	p.noHeaders = noHeaders
+}
+
+// SetCSVDelimiter sets the delimiter of the fields when printing resources as
+// CSV. It must be a single character, or \t for a tab.
+func (p *Printer) SetCSVDelimiter(delimiter *string) {
+	p.csvDelimiter = delimiter
+}
+
+// SetCSVNoHeader controls whether the header row is omitted when printing
+// resources as CSV.
+func (p *Printer) SetCSVNoHeader(noHeader *bool) {
+	p.csvNoHeader = noHeader
+}
+
+// PrintCSV prints the given resource as CSV to out, whatever the format of
+// the printer is. The header row is omitted if noHeader is true or the
+// printer is set to omit it.
+func (p *Printer) PrintCSV(out io.Writer, v interface{}, noHeader bool) error {
+	type csvvaluer interface {
+		MarshalCSVValue() interface{}
+	}
+
+	if c, ok := v.(csvvaluer); ok {
+		v = c.MarshalCSVValue()
+	}
+
+	delimiter := ","
+	if p.csvDelimiter != nil {
+		delimiter = *p.csvDelimiter
+	}
+
+	buf, err := marshalCSV(v, delimiter, noHeader || (p.csvNoHeader != nil && *p.csvNoHeader))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(out, buf)
+	return err
+}
+
 // PrintResource prints the given resource in the format it was specified.
 func (p *Printer) PrintResource(v interface{}) error {
 	if p.format == nil {
@@ -168,7 +250,11 @@ func (p *Printer) PrintResource(v interface{}) error {
 	switch *p.format {
 	case Human:
 		var b strings.Builder
-		tableprinter.Print(&b, v)
+		if p.noHeaders != nil && *p.noHeaders {
+			printRows(&b, v)
+		} else {
+			tableprinter.Print(&b, v)
+		}
 		fmt.Fprintln(out, b.String())
 		return nil
 	case JSON:
@@ -180,23 +266,134 @@ func (p *Printer) PrintResource(v interface{}) error {
 		fmt.Fprintln(out, string(buf))
 		return nil
 	case CSV:
-		type csvvaluer interface {
-			MarshalCSVValue() interface{}
+		if err := p.PrintCSV(out, v, false); err != nil {
+			return err
 		}
-
-		if c, ok := v.(csvvaluer); ok {
-			v = c.MarshalCSVValue()
+		fmt.Fprintln(out)
+		return nil
+	case YAML:
+		buf, err := marshalYAML(v)
+		if err != nil {
+			return err
 		}
 
-		buf, err := gocsv.MarshalString(v)
+		fmt.Fprint(out, string(buf))
+		return nil
+	case JSONLines:
+		return printJSONLines(out, v)
+	case TableBox:
+		printBox(out, v, p.noHeaders != nil && *p.noHeaders)
+		return nil
+	}
+
+	return fmt.Errorf("unknown printer.Format: %T", *p.format)
+}
+
+// marshalYAML returns the YAML encoding of v. Resources define their output
+// through MarshalJSON, so v is encoded as JSON first and converted from there,
+// which keeps the fields of both formats the same.
+func marshalYAML(v interface{}) ([]byte, error) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out interface{}
+	if err := yaml.Unmarshal(buf, &out); err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(out)
+}
+
+// marshalCSV returns the CSV encoding of v with fields separated by
+// delimiter. Fields that contain the delimiter are quoted.
+func marshalCSV(v interface{}, delimiter string, noHeader bool) (string, error) {
+	comma, err := csvDelimiter(delimiter)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Comma = comma
+
+	if noHeader {
+		err = gocsv.MarshalCSVWithoutHeaders(v, gocsv.NewSafeCSVWriter(w))
+	} else {
+		err = gocsv.MarshalCSV(v, gocsv.NewSafeCSVWriter(w))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// csvDelimiter parses the delimiter of CSV fields. It's a single character,
+// or \t for a tab, as a tab is hard to pass on the command line.
+func csvDelimiter(s string) (rune, error) {
+	if s == `\t` {
+		return '\t', nil
+	}
+
+	runes := []rune(s)
+	if len(runes) != 1 {
+		return 0, fmt.Errorf("invalid CSV delimiter %q, must be a single character", s)
+	}
+
+	switch r := runes[0]; r {
+	case '"', '\r', '\n', utf8.RuneError:
+		return 0, fmt.Errorf("invalid CSV delimiter %q", s)
+	default:
+		return r, nil
+	}
+}
+
+// printJSONLines prints every element of v on its own line if it's a slice,
+// or v on a single line otherwise.
+func printJSONLines(w io.Writer, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		buf, err := json.Marshal(v)
 		if err != nil {
 			return err
 		}
-		fmt.Fprintln(out, buf)
+
+		fmt.Fprintln(w, string(buf))
 		return nil
 	}
 
-	return fmt.Errorf("unknown printer.Format: %T", *p.format)
+	for i := 0; i < rv.Len(); i++ {
+		buf, err := json.Marshal(rv.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(w, string(buf))
+	}
+
+	return nil
+}
+
+// printRows prints the given resource as a table without the header row.
+func printRows(w io.Writer, v interface{}) {
+	rv := reflect.ValueOf(v)
+	if kind := rv.Kind(); kind == reflect.Interface || kind == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	parser := tableprinter.WhichParser(rv.Type())
+	if parser == nil {
+		return
+	}
+
+	_, rows, nums := parser.Parse(rv, nil)
+	if len(rows) == 0 {
+		return
+	}
+
+	tableprinter.New(w).Render(nil, rows, nums, true)
 }
 
 func GetMilliseconds(timestamp time.Time) int64 {
@@ -0,0 +1,167 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestNoColor(t *testing.T) {
+	c := qt.New(t)
+
+	// tests don't run with a terminal as stdout, so colors are disabled
+	// unless they're forced on.
+	c.Assert(color.NoColor, qt.IsTrue)
+
+	c.Run("disabled", func(c *qt.C) {
+		c.Assert(BoldBlue("foo"), qt.Equals, "foo")
+		c.Assert(BoldRed("foo"), qt.Equals, "foo")
+		c.Assert(Bold("foo"), qt.Equals, "foo")
+	})
+
+	c.Run("enabled", func(c *qt.C) {
+		color.NoColor = false
+		c.Cleanup(func() { color.NoColor = true })
+
+		c.Assert(BoldBlue("foo"), qt.Not(qt.Equals), "foo")
+	})
+}
+
+func TestIsHuman(t *testing.T) {
+	c := qt.New(t)
+
+	for _, format := range []Format{Human, TableBox} {
+		c.Assert(NewPrinter(&format).IsHuman(), qt.IsTrue, qt.Commentf("format %s", format.String()))
+	}
+	for _, format := range []Format{JSON, CSV, YAML, JSONLines} {
+		c.Assert(NewPrinter(&format).IsHuman(), qt.IsFalse, qt.Commentf("format %s", format.String()))
+	}
+}
+
+func TestPrintResource_TableBox(t *testing.T) {
+	c := qt.New(t)
+
+	type org struct {
+		Name   string `header:"name"`
+		Region string `header:"region"`
+	}
+
+	var buf bytes.Buffer
+	format := TableBox
+	p := NewPrinter(&format)
+	p.SetResourceOutput(&buf)
+
+	err := p.PrintResource([]*org{
+		{Name: "planetscale", Region: "us-east"},
+		{Name: "acme", Region: "eu-west"},
+	})
+	c.Assert(err, qt.IsNil)
+
+	want := `┌─────────────┬─────────┐
+│ NAME        │ REGION  │
+├─────────────┼─────────┤
+│ planetscale │ us-east │
+│ acme        │ eu-west │
+└─────────────┴─────────┘
+`
+	c.Assert(buf.String(), qt.Equals, want)
+}
+
+func TestPrintResource_CSVDelimiter(t *testing.T) {
+	c := qt.New(t)
+
+	type org struct {
+		Name  string `csv:"name"`
+		Notes string `csv:"notes"`
+	}
+
+	orgs := []*org{
+		{Name: "planetscale", Notes: "tabs\tand, commas"},
+		{Name: "acme", Notes: "none"},
+	}
+
+	tests := []struct {
+		name      string
+		delimiter string
+		noHeader  bool
+		want      string
+	}{
+		{name: "default", delimiter: ",", want: "name,notes\nplanetscale,\"tabs\tand, commas\"\nacme,none\n\n"},
+		{name: "tab", delimiter: `\t`, want: "name\tnotes\nplanetscale\t\"tabs\tand, commas\"\nacme\tnone\n\n"},
+		{name: "no header", delimiter: ";", noHeader: true, want: "planetscale;tabs\tand, commas\nacme;none\n\n"},
+	}
+
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			var buf bytes.Buffer
+			format := CSV
+			p := NewPrinter(&format)
+			p.SetResourceOutput(&buf)
+			p.SetCSVDelimiter(&tt.delimiter)
+			p.SetCSVNoHeader(&tt.noHeader)
+
+			err := p.PrintResource(orgs)
+			c.Assert(err, qt.IsNil)
+			c.Assert(buf.String(), qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestPrintResource_CSVInvalidDelimiter(t *testing.T) {
+	c := qt.New(t)
+
+	format := CSV
+	p := NewPrinter(&format)
+	p.SetResourceOutput(ioutil.Discard)
+
+	delimiter := "ab"
+	p.SetCSVDelimiter(&delimiter)
+
+	err := p.PrintResource([]string{})
+	c.Assert(err, qt.ErrorMatches, `invalid CSV delimiter "ab", must be a single character`)
+}
+
+func TestJSONSchema(t *testing.T) {
+	c := qt.New(t)
+
+	type Inner struct {
+		ID string `json:"id"`
+	}
+
+	type Resource struct {
+		Inner
+		Name      string            `json:"name"`
+		Size      int64             `json:"size"`
+		Ready     bool              `json:"ready"`
+		Tags      []string          `json:"tags,omitempty"`
+		Labels    map[string]string `json:"labels"`
+		CreatedAt time.Time         `json:"created_at"`
+		DeletedAt *time.Time        `json:"deleted_at"`
+		Secret    string            `json:"-"`
+		internal  string
+	}
+
+	out, err := json.Marshal(JSONSchema(&Resource{}))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(out), qt.JSONEquals, map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "Resource",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"id":         map[string]interface{}{"type": "string"},
+			"name":       map[string]interface{}{"type": "string"},
+			"size":       map[string]interface{}{"type": "integer"},
+			"ready":      map[string]interface{}{"type": "boolean"},
+			"tags":       map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			"labels":     map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}},
+			"created_at": map[string]interface{}{"type": "string", "format": "date-time"},
+			"deleted_at": map[string]interface{}{"type": []string{"string", "null"}, "format": "date-time"},
+		},
+		"required": []string{"id", "name", "size", "ready", "labels", "created_at", "deleted_at"},
+	})
+}
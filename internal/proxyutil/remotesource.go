@@ -24,6 +24,9 @@ func NewRemoteCertSource(client *ps.Client) *RemoteCertSource {
 	}
 }
 
+// ProxyPort is the port of the remote proxy of a branch.
+const ProxyPort = 3307
+
 const publicIdAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
 const publicIdLength = 6
 
@@ -55,7 +58,7 @@ func (r *RemoteCertSource) Cert(ctx context.Context, org, db, branch string) (*p
 		ClientCert: tlsPair,
 		AccessHost: cert.Branch.AccessHostURL,
 		Ports: proxy.RemotePorts{
-			Proxy: 3307,
+			Proxy: ProxyPort,
 			MySQL: 3306,
 		},
 	}, nil
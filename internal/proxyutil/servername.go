@@ -0,0 +1,35 @@
+package proxyutil
+
+import (
+	"context"
+
+	"github.com/planetscale/sql-proxy/proxy"
+)
+
+// ServerNameCertSource wraps a proxy.CertSource and overrides the access host
+// of the certificates it returns. The proxy uses the access host as the TLS
+// server name, so this changes the SNI sent to the remote server.
+type ServerNameCertSource struct {
+	source     proxy.CertSource
+	serverName string
+}
+
+// NewServerNameCertSource returns a cert source that returns the certificates
+// of source, with serverName as the access host.
+func NewServerNameCertSource(source proxy.CertSource, serverName string) *ServerNameCertSource {
+	return &ServerNameCertSource{
+		source:     source,
+		serverName: serverName,
+	}
+}
+
+func (s *ServerNameCertSource) Cert(ctx context.Context, org, db, branch string) (*proxy.Cert, error) {
+	cert, err := s.source.Cert(ctx, org, db, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	c := *cert
+	c.AccessHost = s.serverName
+	return &c, nil
+}
@@ -0,0 +1,35 @@
+package proxyutil
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/planetscale/sql-proxy/proxy"
+)
+
+type stubCertSource struct {
+	cert *proxy.Cert
+}
+
+func (s stubCertSource) Cert(ctx context.Context, org, db, branch string) (*proxy.Cert, error) {
+	return s.cert, nil
+}
+
+func TestServerNameCertSource(t *testing.T) {
+	c := qt.New(t)
+
+	cert := &proxy.Cert{
+		AccessHost: "abc.us-east-1.psdb.cloud",
+		Ports:      proxy.RemotePorts{Proxy: ProxyPort},
+	}
+
+	src := NewServerNameCertSource(stubCertSource{cert: cert}, "db.example.com")
+	got, err := src.Cert(context.Background(), "org", "db", "main")
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.AccessHost, qt.Equals, "db.example.com")
+	c.Assert(got.Ports.Proxy, qt.Equals, ProxyPort)
+
+	// the wrapped certificate isn't modified
+	c.Assert(cert.AccessHost, qt.Equals, "abc.us-east-1.psdb.cloud")
+}
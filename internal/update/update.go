@@ -7,6 +7,7 @@ package update
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -16,7 +17,6 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/hashicorp/go-version"
-	"github.com/planetscale/cli/internal/cmdutil"
 	"github.com/planetscale/cli/internal/config"
 	"gopkg.in/yaml.v2"
 
@@ -27,6 +27,10 @@ type UpdateInfo struct {
 	Update      bool
 	Reason      string
 	ReleaseInfo *ReleaseInfo
+
+	// fetched is true if ReleaseInfo was fetched from the GitHub API, rather
+	// than read from the state file, and should be recorded there.
+	fetched bool
 }
 
 // ReleaseInfo stores information about a release
@@ -43,32 +47,108 @@ type StateEntry struct {
 	LatestRelease      ReleaseInfo `yaml:"latest_release"`
 }
 
-// CheckVersion checks for the given build version whether there is a new
-// version of the CLI or not.
-func CheckVersion(ctx context.Context, buildVersion string) error {
-	if ctx.Err() != nil {
-		return ctx.Err()
+// errRateLimited is returned by latestVersion when the GitHub API has rate
+// limited the request.
+var errRateLimited = errors.New("rate limited by the GitHub API")
+
+// staleReleaseAge is how long the release recorded in the state file is used
+// in place of the latest one when the GitHub API is rate limiting us.
+const staleReleaseAge = 7 * 24 * time.Hour
+
+// checkTimeout is the hard deadline for checking the latest version. The
+// check runs while the command is executing, so this is the longest it can
+// delay exiting.
+const checkTimeout = 3 * time.Second
+
+// CheckVersion starts checking in the background whether there is a new
+// version of the CLI for the given build version. The returned function
+// should be called once the command has finished, if the notice can be
+// printed. It waits for the check to complete or time out, records the check
+// in the state file and prints a notice if there is a new version. The check
+// isn't recorded if the returned function isn't called.
+func CheckVersion(ctx context.Context, buildVersion string) func() error {
+	if _, exists := os.LookupEnv("PSCALE_NO_UPDATE_NOTIFIER"); exists {
+		return func() error {
+			return errors.New("skipping update, reason: PSCALE_NO_UPDATE_NOTIFIER is set")
+		}
 	}
 
 	path, err := stateFilePath()
 	if err != nil {
-		return err
+		return func() error { return err }
 	}
 
-	updateInfo, err := checkVersion(
-		ctx,
-		buildVersion,
-		path,
-		latestVersion,
-	)
-	if err != nil {
-		return fmt.Errorf("skipping update, error: %s", err)
+	wait := checkVersionInBackground(ctx, buildVersion, path, latestVersion, checkTimeout)
+
+	return func() error {
+		updateInfo, err := wait()
+		if err != nil {
+			return fmt.Errorf("skipping update, error: %s", err)
+		}
+
+		if !updateInfo.Update {
+			return fmt.Errorf("skipping update, reason: %s", updateInfo.Reason)
+		}
+
+		printUpdateNotice(buildVersion, updateInfo)
+		return nil
+	}
+}
+
+// checkVersionInBackground runs checkVersion in a goroutine. The returned
+// function blocks until the check is done or the timeout has passed, and
+// records a release fetched from the GitHub API in the state file.
+func checkVersionInBackground(
+	ctx context.Context,
+	buildVersion, path string,
+	latestVersionFn func(ctx context.Context, addr string) (*ReleaseInfo, error),
+	timeout time.Duration,
+) func() (*UpdateInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	type result struct {
+		info *UpdateInfo
+		err  error
 	}
 
-	if !updateInfo.Update {
-		return fmt.Errorf("skipping update, reason: %s", updateInfo.Reason)
+	done := make(chan result, 1)
+	go func() {
+		info, err := checkVersion(ctx, buildVersion, path, latestVersionFn)
+		done <- result{info: info, err: err}
+	}()
+
+	return func() (*UpdateInfo, error) {
+		defer cancel()
+
+		// the timeout starts with the command, so it has often expired by
+		// the time the command finishes. A check that is already done wins
+		// over the timeout, rather than select picking one of them at random.
+		var res result
+		select {
+		case res = <-done:
+		default:
+			select {
+			case res = <-done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		if res.err != nil {
+			return nil, res.err
+		}
+
+		if res.info.fetched {
+			if err := setStateEntry(path, time.Now(), *res.info.ReleaseInfo); err != nil {
+				return nil, err
+			}
+		}
+
+		return res.info, nil
 	}
+}
 
+func printUpdateNotice(buildVersion string, updateInfo *UpdateInfo) {
 	fmt.Fprintf(color.Error, "\n%s %s → %s\n",
 		color.BlueString("A new release of pscale is available:"),
 		color.CyanString(buildVersion),
@@ -81,11 +161,10 @@ func CheckVersion(ctx context.Context, buildVersion string) error {
 		binpath = path
 	}
 
-	if cmdutil.IsUnderHomebrew(binpath) {
-		fmt.Fprintf(os.Stderr, "To upgrade, run: %s\n", "brew update && brew upgrade pscale")
+	if upgradeCmd := packageManagerUpgradeCmd(binpath, upgradeDetectors); upgradeCmd != "" {
+		fmt.Fprintf(os.Stderr, "To upgrade, run: %s\n", upgradeCmd)
 	}
 	fmt.Fprintf(color.Error, "%s\n", color.YellowString(updateInfo.ReleaseInfo.URL))
-	return nil
 }
 
 func checkVersion(
@@ -110,13 +189,26 @@ func checkVersion(
 
 	addr := "https://api.github.com/repos/planetscale/cli/releases/latest"
 	info, err := latestVersionFn(ctx, addr)
-	if err != nil {
-		return nil, err
-	}
 
-	err = setStateEntry(path, time.Now(), *info)
-	if err != nil {
+	// only a release fetched from the GitHub API is recorded in the state
+	// file, a stale one read from it keeps its original check time.
+	var fetched bool
+	switch {
+	case errors.Is(err, errRateLimited):
+		// rate limits are common in CI, where many jobs share an IP address,
+		// so fall back to the last release we know about, or skip the check.
+		if stateEntry == nil || stateEntry.LatestRelease.Version == "" ||
+			time.Since(stateEntry.CheckedForUpdateAt) > staleReleaseAge {
+			return &UpdateInfo{
+				Update: false,
+				Reason: "GitHub API rate limit reached",
+			}, nil
+		}
+		info = &stateEntry.LatestRelease
+	case err != nil:
 		return nil, err
+	default:
+		fetched = true
 	}
 
 	v1, err := version.NewVersion(info.Version)
@@ -135,6 +227,7 @@ func checkVersion(
 			Reason: fmt.Sprintf("Latest version (%s) is less than or equal to current build version (%s)",
 				info.Version, buildVersion),
 			ReleaseInfo: info,
+			fetched:     fetched,
 		}, nil
 	}
 
@@ -143,6 +236,7 @@ func checkVersion(
 		Reason: fmt.Sprintf("Latest version (%s) is greater than the current build version (%s)",
 			info.Version, buildVersion),
 		ReleaseInfo: info,
+		fetched:     fetched,
 	}, nil
 
 }
@@ -179,6 +273,11 @@ func latestVersion(ctx context.Context, addr string) (*ReleaseInfo, error) {
 		return nil, err
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0") {
+		return nil, errRateLimited
+	}
+
 	success := resp.StatusCode >= 200 && resp.StatusCode < 300
 	if !success {
 		return nil, fmt.Errorf("error fetching latest release: %v", string(out))
@@ -31,6 +31,10 @@ func TestLatestVersion(t *testing.T) {
 			name:       "non valid response",
 			statusCode: 400,
 		},
+		{
+			name:       "rate limited",
+			statusCode: 429,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -120,3 +124,143 @@ func TestCheckVersion(t *testing.T) {
 	}
 
 }
+
+func TestLatestVersion_RateLimited(t *testing.T) {
+	c := qt.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	}))
+	defer ts.Close()
+
+	_, err := latestVersion(context.Background(), ts.URL)
+	c.Assert(err, qt.ErrorIs, errRateLimited)
+}
+
+func TestCheckVersion_RateLimited(t *testing.T) {
+	c := qt.New(t)
+
+	var tests = []struct {
+		name        string
+		lastChecked time.Time
+		update      bool
+	}{
+		{
+			name:   "no state file",
+			update: false,
+		},
+		{
+			name:        "recent state file",
+			lastChecked: time.Now().Add(-3 * 24 * time.Hour),
+			update:      true,
+		},
+		{
+			name:        "stale state file",
+			lastChecked: time.Now().Add(-8 * 24 * time.Hour),
+			update:      false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state.yml")
+
+			if !tt.lastChecked.IsZero() {
+				err := setStateEntry(path, tt.lastChecked, ReleaseInfo{Version: "v0.2.0"})
+				c.Assert(err, qt.IsNil)
+			}
+
+			updateInfo, err := checkVersion(
+				context.Background(),
+				"v0.1.0",
+				path,
+				func(ctx context.Context, addr string) (*ReleaseInfo, error) {
+					return nil, errRateLimited
+				},
+			)
+
+			c.Assert(err, qt.IsNil)
+			c.Assert(updateInfo.Update, qt.Equals, tt.update, qt.Commentf("reason: %s", updateInfo.Reason))
+		})
+	}
+}
+
+func TestCheckVersionInBackground_Timeout(t *testing.T) {
+	c := qt.New(t)
+
+	path := filepath.Join(t.TempDir(), "state.yml")
+
+	wait := checkVersionInBackground(
+		context.Background(),
+		"v0.1.0",
+		path,
+		func(ctx context.Context, addr string) (*ReleaseInfo, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+		10*time.Millisecond,
+	)
+
+	start := time.Now()
+	updateInfo, err := wait()
+
+	c.Assert(err, qt.ErrorIs, context.DeadlineExceeded)
+	c.Assert(updateInfo, qt.IsNil)
+	c.Assert(time.Since(start) < time.Second, qt.IsTrue)
+}
+
+func TestCheckVersionInBackground(t *testing.T) {
+	c := qt.New(t)
+
+	path := filepath.Join(t.TempDir(), "state.yml")
+
+	wait := checkVersionInBackground(
+		context.Background(),
+		"v0.1.0",
+		path,
+		func(ctx context.Context, addr string) (*ReleaseInfo, error) {
+			return &ReleaseInfo{Version: "v0.2.0"}, nil
+		},
+		time.Second,
+	)
+
+	updateInfo, err := wait()
+	c.Assert(err, qt.IsNil)
+	c.Assert(updateInfo.Update, qt.IsTrue)
+}
+
+func TestCheckVersionInBackground_DoneAfterTimeout(t *testing.T) {
+	c := qt.New(t)
+
+	path := filepath.Join(t.TempDir(), "state.yml")
+
+	checked := make(chan struct{})
+	wait := checkVersionInBackground(
+		context.Background(),
+		"v0.1.0",
+		path,
+		func(ctx context.Context, addr string) (*ReleaseInfo, error) {
+			defer close(checked)
+			return &ReleaseInfo{Version: "v0.2.0"}, nil
+		},
+		10*time.Millisecond,
+	)
+
+	// the command outlives the timeout, which must not hide the result of a
+	// check that has already finished.
+	<-checked
+	time.Sleep(50 * time.Millisecond)
+
+	_, err := getStateEntry(path)
+	c.Assert(err, qt.Not(qt.IsNil), qt.Commentf("the check is only recorded once waited on"))
+
+	updateInfo, err := wait()
+	c.Assert(err, qt.IsNil)
+	c.Assert(updateInfo.Update, qt.IsTrue)
+
+	stateEntry, err := getStateEntry(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(stateEntry.LatestRelease.Version, qt.Equals, "v0.2.0")
+}
@@ -0,0 +1,83 @@
+package update
+
+import (
+	"os"
+	"strings"
+
+	"github.com/planetscale/cli/internal/cmdutil"
+)
+
+// upgradeDetector detects whether pscale was installed by a package manager.
+type upgradeDetector interface {
+	// upgradeCommand returns the command that upgrades the binary at
+	// binpath, if it was installed by this package manager.
+	upgradeCommand(binpath string) (string, bool)
+}
+
+// upgradeDetectors are checked in order, the first one that matches is used.
+var upgradeDetectors = []upgradeDetector{
+	homebrewDetector{isUnderHomebrew: cmdutil.IsUnderHomebrew},
+	scoopDetector{},
+	debDetector{listFile: "/var/lib/dpkg/info/pscale.list"},
+}
+
+// packageManagerUpgradeCmd returns the command to upgrade the binary at
+// binpath with the package manager that installed it. It returns an empty
+// string if none of the detectors match, e.g. for manual installs.
+func packageManagerUpgradeCmd(binpath string, detectors []upgradeDetector) string {
+	if binpath == "" {
+		return ""
+	}
+
+	for _, d := range detectors {
+		if cmd, ok := d.upgradeCommand(binpath); ok {
+			return cmd
+		}
+	}
+
+	return ""
+}
+
+type homebrewDetector struct {
+	isUnderHomebrew func(binpath string) bool
+}
+
+func (h homebrewDetector) upgradeCommand(binpath string) (string, bool) {
+	if !h.isUnderHomebrew(binpath) {
+		return "", false
+	}
+	return "brew update && brew upgrade pscale", true
+}
+
+// scoopDetector detects installs by Scoop, which keeps every app under
+// scoop\apps\<name>, e.g. C:\Users\me\scoop\apps\pscale\current\pscale.exe.
+type scoopDetector struct{}
+
+func (scoopDetector) upgradeCommand(binpath string) (string, bool) {
+	p := strings.ToLower(strings.ReplaceAll(binpath, `\`, "/"))
+	if !strings.Contains(p, "/scoop/apps/pscale/") {
+		return "", false
+	}
+	return "scoop update pscale", true
+}
+
+// debDetector detects installs from the Debian package, which dpkg records
+// in a list file of the package's files.
+type debDetector struct {
+	listFile string
+}
+
+func (d debDetector) upgradeCommand(binpath string) (string, bool) {
+	content, err := os.ReadFile(d.listFile)
+	if err != nil {
+		return "", false
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == binpath {
+			return "sudo apt-get update && sudo apt-get install --only-upgrade pscale", true
+		}
+	}
+
+	return "", false
+}
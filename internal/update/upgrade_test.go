@@ -0,0 +1,69 @@
+package update
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestPackageManagerUpgradeCmd(t *testing.T) {
+	listFile := filepath.Join(t.TempDir(), "pscale.list")
+	err := os.WriteFile(listFile, []byte("/.\n/usr\n/usr/bin\n/usr/bin/pscale\n"), 0644)
+	qt.Assert(t, err, qt.IsNil)
+
+	detectors := []upgradeDetector{
+		homebrewDetector{isUnderHomebrew: func(binpath string) bool {
+			return binpath == "/opt/homebrew/bin/pscale"
+		}},
+		scoopDetector{},
+		debDetector{listFile: listFile},
+	}
+
+	tests := []struct {
+		name    string
+		binpath string
+		want    string
+	}{
+		{
+			name:    "homebrew",
+			binpath: "/opt/homebrew/bin/pscale",
+			want:    "brew update && brew upgrade pscale",
+		},
+		{
+			name:    "scoop",
+			binpath: `C:\Users\me\scoop\apps\pscale\current\pscale.exe`,
+			want:    "scoop update pscale",
+		},
+		{
+			name:    "deb",
+			binpath: "/usr/bin/pscale",
+			want:    "sudo apt-get update && sudo apt-get install --only-upgrade pscale",
+		},
+		{
+			name:    "manual install",
+			binpath: "/home/me/bin/pscale",
+			want:    "",
+		},
+		{
+			name:    "unknown path",
+			binpath: "",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := packageManagerUpgradeCmd(tt.binpath, detectors)
+			qt.Assert(t, got, qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestDebDetector_MissingListFile(t *testing.T) {
+	d := debDetector{listFile: filepath.Join(t.TempDir(), "pscale.list")}
+
+	_, ok := d.upgradeCommand("/usr/bin/pscale")
+	qt.Assert(t, ok, qt.IsFalse)
+}